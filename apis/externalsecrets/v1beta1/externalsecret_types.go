@@ -223,8 +223,109 @@ type ExternalSecretDataRemoteRef struct {
 	// Used to define a decoding Strategy
 	// +kubebuilder:default="None"
 	DecodingStrategy ExternalSecretDecodingStrategy `json:"decodingStrategy,omitempty"`
+
+	// +optional
+	// Used to reject empty values from the Provider, if supported. Defaults to true
+	AllowEmpty *bool `json:"allowEmpty,omitempty"`
+
+	// +optional
+	// Used to combine several properties of a JSON secret into a single value, if supported.
+	// Each {path} placeholder is replaced with the property at that path, e.g. postgres://{user}:{pass}@{host}/{db}
+	Format string `json:"format,omitempty"`
+
+	// +optional
+	// Used to transform the keys extracted from a Provider value, possible options are None, Upper, Lower. Defaults to None
+	KeyTransform ExternalSecretKeyTransform `json:"keyTransform,omitempty"`
+
+	// +optional
+	// Used to convert a JSON Provider value to another format, if supported, possible options are Raw, YAML, CanonicalJSON. Defaults to Raw
+	OutputFormat ExternalSecretOutputFormat `json:"outputFormat,omitempty"`
+
+	// +optional
+	// Used with an empty version to briefly retry until the Provider returns this version, if supported.
+	// Smooths over the propagation delay of a newly rotated version.
+	ExpectedVersion string `json:"expectedVersion,omitempty"`
+
+	// +optional
+	// Used instead of the Provider value when the secret does not exist, if supported.
+	// Other errors, like missing permissions, are never replaced by the default.
+	DefaultValue *string `json:"defaultValue,omitempty"`
+
+	// +optional
+	// Used instead of the property value when the property does not exist in the Provider value, if supported.
+	// Set to an empty string to make the property optional.
+	PropertyDefault *string `json:"propertyDefault,omitempty"`
+
+	// +optional
+	// Used to reject Provider values that do not conform to a format, if supported, possible options are PEMCertificate, RSAPrivateKey, JSON, URL
+	ValidateAs ExternalSecretValidateAs `json:"validateAs,omitempty"`
+
+	// +optional
+	// Used to convert CRLF line endings of the Provider value to LF, if supported.
+	NormalizeLineEndings bool `json:"normalizeLineEndings,omitempty"`
+
+	// +optional
+	// Used to URL-decode the Provider value, e.g. connection strings with escaped special characters, if supported.
+	URLDecode bool `json:"urlDecode,omitempty"`
+
+	// +optional
+	// Used to remove a prefix, like a "Bearer " scheme, from the start of the Provider value when present, if supported.
+	StripPrefix string `json:"stripPrefix,omitempty"`
+
+	// +optional
+	// Used to remove a suffix from the end of the Provider value when present, if supported.
+	StripSuffix string `json:"stripSuffix,omitempty"`
+
+	// +optional
+	// Used with dataFrom.extract to add the tags, content type and update time of the Provider value
+	// as __tags, __contentType and __updated keys, if supported. A value which is not a JSON object
+	// then only returns these keys instead of failing.
+	IncludeMetadata bool `json:"includeMetadata,omitempty"`
+
+	// +optional
+	// Used with dataFrom.extract to return the tags of the Provider value keyed by tag name
+	// instead of parsing its value, if supported.
+	FromTags bool `json:"fromTags,omitempty"`
+
+	// +optional
+	// Used instead of Property to select the keys of a JSON Provider value by a regular expression, if supported.
+	PropertyMatch *ExternalSecretPropertyMatch `json:"propertyMatch,omitempty"`
+
+	// +optional
+	// Used with dataFrom.extract to return every leaf of a nested JSON Provider value
+	// under its path, like db.primary.password, if supported.
+	NestedFlatten *ExternalSecretNestedFlatten `json:"nestedFlatten,omitempty"`
+}
+
+// ExternalSecretNestedFlatten flattens nested JSON objects and arrays into one key per leaf.
+type ExternalSecretNestedFlatten struct {
+	// +optional
+	// Used to join the keys and array indices of the path to a leaf. Defaults to "."
+	// +kubebuilder:default="."
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Separator string `json:"separator,omitempty"`
 }
 
+// ExternalSecretPropertyMatch selects the top level keys of a JSON object matching a regular expression.
+type ExternalSecretPropertyMatch struct {
+	// Regular expression matched against the keys of the JSON object.
+	RegExp string `json:"regexp"`
+
+	// +optional
+	// Used to return the value of the First matching key in document order,
+	// or All matching keys and their values as a JSON object. Defaults to First
+	// +kubebuilder:validation:Enum=First;All
+	// +kubebuilder:default="First"
+	Mode ExternalSecretPropertyMatchMode `json:"mode,omitempty"`
+}
+
+type ExternalSecretPropertyMatchMode string
+
+const (
+	ExternalSecretPropertyMatchFirst ExternalSecretPropertyMatchMode = "First"
+	ExternalSecretPropertyMatchAll   ExternalSecretPropertyMatchMode = "All"
+)
+
 type ExternalSecretMetadataPolicy string
 
 const (
@@ -239,6 +340,47 @@ const (
 	ExternalSecretConversionUnicode ExternalSecretConversionStrategy = "Unicode"
 )
 
+// +kubebuilder:validation:Enum=None;Upper;Lower
+type ExternalSecretKeyTransform string
+
+const (
+	ExternalSecretKeyTransformNone  ExternalSecretKeyTransform = "None"
+	ExternalSecretKeyTransformUpper ExternalSecretKeyTransform = "Upper"
+	ExternalSecretKeyTransformLower ExternalSecretKeyTransform = "Lower"
+)
+
+// +kubebuilder:validation:Enum=All;Any;KeyOnly
+type ExternalSecretFindTagMatch string
+
+const (
+	// Every tag must be present with the given value.
+	ExternalSecretFindTagMatchAll ExternalSecretFindTagMatch = "All"
+	// At least one tag must be present with the given value.
+	ExternalSecretFindTagMatchAny ExternalSecretFindTagMatch = "Any"
+	// Every tag must be present, their values are ignored.
+	ExternalSecretFindTagMatchKeyOnly ExternalSecretFindTagMatch = "KeyOnly"
+)
+
+// +kubebuilder:validation:Enum=Raw;YAML;CanonicalJSON
+type ExternalSecretOutputFormat string
+
+const (
+	ExternalSecretOutputFormatRaw  ExternalSecretOutputFormat = "Raw"
+	ExternalSecretOutputFormatYAML ExternalSecretOutputFormat = "YAML"
+	// Sorted object keys and no insignificant whitespace, values which are not valid JSON are returned as is.
+	ExternalSecretOutputFormatCanonicalJSON ExternalSecretOutputFormat = "CanonicalJSON"
+)
+
+// +kubebuilder:validation:Enum=PEMCertificate;RSAPrivateKey;JSON;URL
+type ExternalSecretValidateAs string
+
+const (
+	ExternalSecretValidateAsPEMCertificate ExternalSecretValidateAs = "PEMCertificate"
+	ExternalSecretValidateAsRSAPrivateKey  ExternalSecretValidateAs = "RSAPrivateKey"
+	ExternalSecretValidateAsJSON           ExternalSecretValidateAs = "JSON"
+	ExternalSecretValidateAsURL            ExternalSecretValidateAs = "URL"
+)
+
 type ExternalSecretDecodingStrategy string
 
 const (
@@ -297,6 +439,19 @@ type ExternalSecretFind struct {
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
 
+	// +optional
+	// Used to define how tags are matched, possible options are All, Any and KeyOnly, if supported. Defaults to All
+	// +kubebuilder:default="All"
+	TagMatch ExternalSecretFindTagMatch `json:"tagMatch,omitempty"`
+
+	// +optional
+	// Skips secrets which have expired or are not yet active, if supported.
+	SkipExpired bool `json:"skipExpired,omitempty"`
+
+	// +optional
+	// Type of the objects to find, e.g. cert to find certificates instead of secrets, if supported.
+	ObjectType string `json:"objectType,omitempty"`
+
 	// +optional
 	// Used to define a conversion Strategy
 	// +kubebuilder:default="Default"
@@ -312,6 +467,16 @@ type FindName struct {
 	// Finds secrets base
 	// +optional
 	RegExp string `json:"regexp,omitempty"`
+
+	// Finds secrets whose name starts with the prefix, only supported by the Azure Key Vault provider.
+	// When combined with regexp or suffix, all of them must match.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Finds secrets whose name ends with the suffix, only supported by the Azure Key Vault provider.
+	// When combined with regexp or prefix, all of them must match.
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
 }
 
 // ExternalSecretSpec defines the desired state of ExternalSecret.
@@ -14,7 +14,11 @@ limitations under the License.
 
 package v1beta1
 
-import smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
 
 // AuthType describes how to authenticate to the Azure Keyvault
 // Only one of the following auth types may be specified.
@@ -48,6 +52,33 @@ const (
 	AzureEnvironmentGermanCloud       AzureEnvironmentType = "GermanCloud"
 )
 
+// AzureKVKeystoreFormat specifies the keystore format returned for the cert-keystore object type.
+// +kubebuilder:validation:Enum=PKCS12;JKS
+type AzureKVKeystoreFormat string
+
+const (
+	AzureKVKeystorePKCS12 AzureKVKeystoreFormat = "PKCS12"
+	AzureKVKeystoreJKS    AzureKVKeystoreFormat = "JKS"
+)
+
+// AzureKVMaxSecretAgePolicy specifies what happens when a secret is older than MaxSecretAge.
+// +kubebuilder:validation:Enum=Fail;Warn
+type AzureKVMaxSecretAgePolicy string
+
+const (
+	AzureKVMaxSecretAgeFail AzureKVMaxSecretAgePolicy = "Fail"
+	AzureKVMaxSecretAgeWarn AzureKVMaxSecretAgePolicy = "Warn"
+)
+
+// AzureKVCertificateNameGuard specifies what happens when a secret is read which shares its name with a certificate.
+// +kubebuilder:validation:Enum=Warn;Fail
+type AzureKVCertificateNameGuard string
+
+const (
+	AzureKVCertificateNameGuardWarn AzureKVCertificateNameGuard = "Warn"
+	AzureKVCertificateNameGuardFail AzureKVCertificateNameGuard = "Fail"
+)
+
 // Configures an store to sync secrets using Azure KV.
 type AzureKVProvider struct {
 	// Auth type defines how to authenticate to the keyvault service.
@@ -62,6 +93,7 @@ type AzureKVProvider struct {
 	VaultURL *string `json:"vaultUrl"`
 
 	// TenantID configures the Azure Tenant to send requests to. Required for ServicePrincipal auth type.
+	// Use "common" or "organizations" to let AAD resolve the tenant from the service principal.
 	// +optional
 	TenantID *string `json:"tenantId,omitempty"`
 
@@ -84,6 +116,179 @@ type AzureKVProvider struct {
 	// If multiple Managed Identity is assigned to the pod, you can select the one to be used
 	// +optional
 	IdentityID *string `json:"identityId,omitempty"`
+
+	// IdentityResourceID selects the user-assigned Managed Identity by its full resource ID instead of its client ID,
+	// e.g. /subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.ManagedIdentity/userAssignedIdentities/<name>.
+	// It is mutually exclusive with IdentityID.
+	// +optional
+	IdentityResourceID *string `json:"identityResourceId,omitempty"`
+
+	// StrictAuthConfig fails client creation on auth settings ignored by the configured auth type,
+	// like an IdentityID set with an auth type other than ManagedIdentity. By default a warning is logged.
+	// +optional
+	StrictAuthConfig bool `json:"strictAuthConfig,omitempty"`
+
+	// AuthorizerTimeout bounds the time spent acquiring the authorizer when creating the client. Defaults to 30s.
+	// +optional
+	AuthorizerTimeout *metav1.Duration `json:"authorizerTimeout,omitempty"`
+
+	// MSIEndpoint overrides the endpoint used to acquire Managed Identity tokens.
+	// Only used with the ManagedIdentity auth type. Defaults to the IMDS endpoint.
+	// +optional
+	MSIEndpoint *string `json:"msiEndpoint,omitempty"`
+
+	// MSIRetries bounds the retries of a failed Managed Identity authorizer acquisition when creating the client,
+	// e.g. when the IMDS endpoint of a node under pressure times out. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MSIRetries *int32 `json:"msiRetries,omitempty"`
+
+	// MSIRetryInterval is the interval between retries of a failed Managed Identity authorizer acquisition. Defaults to 1s.
+	// +optional
+	MSIRetryInterval *metav1.Duration `json:"msiRetryInterval,omitempty"`
+
+	// RespectNotBefore treats secrets whose NotBefore activation date lies in the future as not found.
+	// +optional
+	RespectNotBefore bool `json:"respectNotBefore,omitempty"`
+
+	// CheckStaleVersion logs a warning when a secret is read with a pinned version
+	// and a newer enabled version of that secret exists.
+	// +optional
+	CheckStaleVersion bool `json:"checkStaleVersion,omitempty"`
+
+	// DataFromCertificates allows dataFrom to extract certificates, returning the certificate chain
+	// and its private key as tls.crt and tls.key. Requires the certificate to have an exportable key.
+	// +optional
+	DataFromCertificates bool `json:"dataFromCertificates,omitempty"`
+
+	// DataFromKeys allows dataFrom to extract keys, returning the components of the JWK, like kty, n and e.
+	// +optional
+	DataFromKeys bool `json:"dataFromKeys,omitempty"`
+
+	// MaxSecretAge is the maximum time since a secret was last updated, or created if it was never updated.
+	// Reading an older secret is handled according to MaxSecretAgePolicy.
+	// +optional
+	MaxSecretAge *metav1.Duration `json:"maxSecretAge,omitempty"`
+
+	// MaxSecretAgePolicy fails reading secrets older than MaxSecretAge, or only logs a warning. Defaults to Fail.
+	// +optional
+	// +kubebuilder:default=Fail
+	MaxSecretAgePolicy AzureKVMaxSecretAgePolicy `json:"maxSecretAgePolicy,omitempty"`
+
+	// CertificateNameGuard checks whether a certificate with the same name exists when reading a secret,
+	// which indicates the cert/ object type was likely intended, and logs a warning or fails the read.
+	// Costs an additional request per secret read. Disabled by default.
+	// +optional
+	CertificateNameGuard AzureKVCertificateNameGuard `json:"certificateNameGuard,omitempty"`
+
+	// AllowedSecrets restricts the secrets this store can read to the given names or regular expressions.
+	// Each entry must match the whole secret name. If empty, all secrets are allowed.
+	// +optional
+	AllowedSecrets []string `json:"allowedSecrets,omitempty"`
+
+	// AllowedVaults lists the names of other vaults in the same cloud that a single ref can read from
+	// with a <vault>: prefix in its key, e.g. vault2:secret/mysecret, using the credentials of this store.
+	// +optional
+	AllowedVaults []string `json:"allowedVaults,omitempty"`
+
+	// NamePattern is a regular expression that must match the whole name of every secret read from this store,
+	// e.g. to enforce naming conventions. Non-conforming names fail before calling Azure.
+	// +optional
+	NamePattern *string `json:"namePattern,omitempty"`
+
+	// ObjectTypeAliases maps additional key prefixes to the built-in object types,
+	// e.g. certificate: cert or pk: key. Aliases must not shadow a built-in object type.
+	// +optional
+	ObjectTypeAliases map[string]string `json:"objectTypeAliases,omitempty"`
+
+	// OwnerID identifies this cluster in the owner tag of pushed secrets.
+	// Pushing to a secret whose owner tag names a different cluster is refused unless ForceOwnership is set.
+	// +optional
+	OwnerID *string `json:"ownerId,omitempty"`
+
+	// ForceOwnership allows pushing to secrets owned by a different cluster, taking over their ownership.
+	// +optional
+	ForceOwnership bool `json:"forceOwnership,omitempty"`
+
+	// RespectOwnership refuses to read secrets whose owner tag names a different cluster than OwnerID.
+	// Secrets without an owner tag can always be read.
+	// +optional
+	RespectOwnership bool `json:"respectOwnership,omitempty"`
+
+	// IncludeDisabled returns information about disabled keys for the key-info object type instead of failing.
+	// +optional
+	IncludeDisabled bool `json:"includeDisabled,omitempty"`
+
+	// MaxIdleConnsPerHost limits the idle connections kept open to the vault. Defaults to 10.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxIdleConnsPerHost *int32 `json:"maxIdleConnsPerHost,omitempty"`
+
+	// ValidateVaultAccess lists the vault on every validation of the store, so rejected credentials
+	// or an unreachable vault mark the store as failed. Validation makes no request to the vault by default.
+	// +optional
+	ValidateVaultAccess bool `json:"validateVaultAccess,omitempty"`
+
+	// IdleConnTimeout is how long idle connections to the vault are kept open. Defaults to 90s.
+	// +optional
+	IdleConnTimeout *metav1.Duration `json:"idleConnTimeout,omitempty"`
+
+	// ClientTimeout bounds every request to the vault, including reading the response,
+	// so a vault behind a firewall dropping packets does not block the sync. Defaults to 30s.
+	// +optional
+	ClientTimeout *metav1.Duration `json:"clientTimeout,omitempty"`
+
+	// MaxResults is the page size used while listing the secrets of the vault, up to 25.
+	// Larger pages need fewer round-trips on large vaults. Defaults to the Azure default.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=25
+	MaxResults *int32 `json:"maxResults,omitempty"`
+
+	// ListRetries overrides MaxRetries for the retries of transient errors, like throttling or server errors,
+	// while paging through the secrets of the vault. Defaults to MaxRetries.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ListRetries *int32 `json:"listRetries,omitempty"`
+
+	// MaxRetries bounds the retries of throttled or failed reads of secrets, keys and certificates.
+	// The Retry-After header of the vault is honored. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// RetryInterval is the initial backoff between retries when the vault sends no Retry-After header,
+	// doubled on every retry. Defaults to 500ms.
+	// +optional
+	RetryInterval *metav1.Duration `json:"retryInterval,omitempty"`
+
+	// FetchConcurrency bounds the secret values fetched in parallel by dataFrom.find. Defaults to 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FetchConcurrency *int32 `json:"fetchConcurrency,omitempty"`
+
+	// CacheTTL caches the fetched secrets for this long, so ExternalSecrets and reconciles
+	// reading the same secret with the same credentials do not each call the vault. Disabled by default.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+
+	// PushRecoverable requires secrets pushed to the vault to be recoverable after deletion when true,
+	// or purgeable when false. The push fails if the vault's recovery level conflicts with it.
+	// +optional
+	PushRecoverable *bool `json:"pushRecoverable,omitempty"`
+
+	// KeySanitize replaces characters that are not allowed in Kubernetes secret keys,
+	// like spaces or slashes in tag names, in the keys returned by GetAllSecrets and GetSecretMap.
+	// +optional
+	KeySanitize *AzureKVKeySanitize `json:"keySanitize,omitempty"`
+
+	// Keystore configures the keystore returned for the cert-keystore object type.
+	// +optional
+	Keystore *AzureKVKeystore `json:"keystore,omitempty"`
+
+	// IntegrityCheck verifies the values of secrets against the SHA-256 checksums stored in sibling secrets.
+	// +optional
+	IntegrityCheck *AzureKVIntegrityCheck `json:"integrityCheck,omitempty"`
 }
 
 // Configuration used to authenticate with Azure.
@@ -95,4 +300,49 @@ type AzureKVAuth struct {
 	// The Azure ClientSecret of the service principle used for authentication.
 	// +optional
 	ClientSecret *smmeta.SecretKeySelector `json:"clientSecret,omitempty"`
+
+	// The client certificate of the service principle used for authentication, instead of a ClientSecret.
+	// Either a PKCS#12 (PFX) archive or PEM blocks with the certificate and its RSA private key.
+	// +optional
+	ClientCertificate *smmeta.SecretKeySelector `json:"clientCertificate,omitempty"`
+
+	// The password of a PKCS#12 ClientCertificate, if any.
+	// +optional
+	ClientCertificatePassword *smmeta.SecretKeySelector `json:"clientCertificatePassword,omitempty"`
+}
+
+// Configuration used to sanitize secret keys.
+type AzureKVKeySanitize struct {
+	// Replacement for every character not allowed in secret keys. Defaults to "_".
+	// +optional
+	// +kubebuilder:default=_
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]*$`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// Configuration used to verify secret values against checksum secrets.
+type AzureKVIntegrityCheck struct {
+	// ChecksumName is the name of the secret holding the hex encoded SHA-256 checksum of a secret,
+	// where {name} is replaced with the name of the secret. Defaults to "{name}-sha256".
+	// +optional
+	ChecksumName string `json:"checksumName,omitempty"`
+
+	// RequireChecksum fails reading secrets without a checksum secret. By default they are read unverified.
+	// +optional
+	RequireChecksum bool `json:"requireChecksum,omitempty"`
+}
+
+// Configuration used to package certificates into a keystore.
+type AzureKVKeystore struct {
+	// Format of the keystore. Valid values are PKCS12 and JKS.
+	// +optional
+	// +kubebuilder:default=PKCS12
+	Format AzureKVKeystoreFormat `json:"format,omitempty"`
+
+	// Alias of the private key entry in JKS keystores. Defaults to the certificate name.
+	// +optional
+	Alias string `json:"alias,omitempty"`
+
+	// PasswordSecretRef references the password protecting the keystore.
+	PasswordSecretRef smmeta.SecretKeySelector `json:"passwordSecretRef"`
 }
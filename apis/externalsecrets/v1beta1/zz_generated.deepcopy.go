@@ -320,6 +320,16 @@ func (in *AzureKVAuth) DeepCopyInto(out *AzureKVAuth) {
 		*out = new(metav1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificatePassword != nil {
+		in, out := &in.ClientCertificatePassword, &out.ClientCertificatePassword
+		*out = new(metav1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKVAuth.
@@ -332,6 +342,52 @@ func (in *AzureKVAuth) DeepCopy() *AzureKVAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKVIntegrityCheck) DeepCopyInto(out *AzureKVIntegrityCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKVIntegrityCheck.
+func (in *AzureKVIntegrityCheck) DeepCopy() *AzureKVIntegrityCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKVIntegrityCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKVKeySanitize) DeepCopyInto(out *AzureKVKeySanitize) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKVKeySanitize.
+func (in *AzureKVKeySanitize) DeepCopy() *AzureKVKeySanitize {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKVKeySanitize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKVKeystore) DeepCopyInto(out *AzureKVKeystore) {
+	*out = *in
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKVKeystore.
+func (in *AzureKVKeystore) DeepCopy() *AzureKVKeystore {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKVKeystore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureKVProvider) DeepCopyInto(out *AzureKVProvider) {
 	*out = *in
@@ -365,6 +421,128 @@ func (in *AzureKVProvider) DeepCopyInto(out *AzureKVProvider) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.IdentityResourceID != nil {
+		in, out := &in.IdentityResourceID, &out.IdentityResourceID
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthorizerTimeout != nil {
+		in, out := &in.AuthorizerTimeout, &out.AuthorizerTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MSIEndpoint != nil {
+		in, out := &in.MSIEndpoint, &out.MSIEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.MSIRetries != nil {
+		in, out := &in.MSIRetries, &out.MSIRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MSIRetryInterval != nil {
+		in, out := &in.MSIRetryInterval, &out.MSIRetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxSecretAge != nil {
+		in, out := &in.MaxSecretAge, &out.MaxSecretAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AllowedSecrets != nil {
+		in, out := &in.AllowedSecrets, &out.AllowedSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedVaults != nil {
+		in, out := &in.AllowedVaults, &out.AllowedVaults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamePattern != nil {
+		in, out := &in.NamePattern, &out.NamePattern
+		*out = new(string)
+		**out = **in
+	}
+	if in.ObjectTypeAliases != nil {
+		in, out := &in.ObjectTypeAliases, &out.ObjectTypeAliases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OwnerID != nil {
+		in, out := &in.OwnerID, &out.OwnerID
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxIdleConnsPerHost != nil {
+		in, out := &in.MaxIdleConnsPerHost, &out.MaxIdleConnsPerHost
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleConnTimeout != nil {
+		in, out := &in.IdleConnTimeout, &out.IdleConnTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ClientTimeout != nil {
+		in, out := &in.ClientTimeout, &out.ClientTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxResults != nil {
+		in, out := &in.MaxResults, &out.MaxResults
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ListRetries != nil {
+		in, out := &in.ListRetries, &out.ListRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryInterval != nil {
+		in, out := &in.RetryInterval, &out.RetryInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FetchConcurrency != nil {
+		in, out := &in.FetchConcurrency, &out.FetchConcurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PushRecoverable != nil {
+		in, out := &in.PushRecoverable, &out.PushRecoverable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KeySanitize != nil {
+		in, out := &in.KeySanitize, &out.KeySanitize
+		*out = new(AzureKVKeySanitize)
+		**out = **in
+	}
+	if in.Keystore != nil {
+		in, out := &in.Keystore, &out.Keystore
+		*out = new(AzureKVKeystore)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrityCheck != nil {
+		in, out := &in.IntegrityCheck, &out.IntegrityCheck
+		*out = new(AzureKVIntegrityCheck)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKVProvider.
@@ -828,7 +1006,7 @@ func (in *ExternalSecret) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretData) DeepCopyInto(out *ExternalSecretData) {
 	*out = *in
-	out.RemoteRef = in.RemoteRef
+	in.RemoteRef.DeepCopyInto(&out.RemoteRef)
 	if in.SourceRef != nil {
 		in, out := &in.SourceRef, &out.SourceRef
 		*out = new(SourceRef)
@@ -852,7 +1030,7 @@ func (in *ExternalSecretDataFromRemoteRef) DeepCopyInto(out *ExternalSecretDataF
 	if in.Extract != nil {
 		in, out := &in.Extract, &out.Extract
 		*out = new(ExternalSecretDataRemoteRef)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Find != nil {
 		in, out := &in.Find, &out.Find
@@ -886,6 +1064,31 @@ func (in *ExternalSecretDataFromRemoteRef) DeepCopy() *ExternalSecretDataFromRem
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretDataRemoteRef) DeepCopyInto(out *ExternalSecretDataRemoteRef) {
 	*out = *in
+	if in.AllowEmpty != nil {
+		in, out := &in.AllowEmpty, &out.AllowEmpty
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultValue != nil {
+		in, out := &in.DefaultValue, &out.DefaultValue
+		*out = new(string)
+		**out = **in
+	}
+	if in.PropertyDefault != nil {
+		in, out := &in.PropertyDefault, &out.PropertyDefault
+		*out = new(string)
+		**out = **in
+	}
+	if in.PropertyMatch != nil {
+		in, out := &in.PropertyMatch, &out.PropertyMatch
+		*out = new(ExternalSecretPropertyMatch)
+		**out = **in
+	}
+	if in.NestedFlatten != nil {
+		in, out := &in.NestedFlatten, &out.NestedFlatten
+		*out = new(ExternalSecretNestedFlatten)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretDataRemoteRef.
@@ -991,6 +1194,36 @@ func (in *ExternalSecretMetadata) DeepCopy() *ExternalSecretMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretNestedFlatten) DeepCopyInto(out *ExternalSecretNestedFlatten) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretNestedFlatten.
+func (in *ExternalSecretNestedFlatten) DeepCopy() *ExternalSecretNestedFlatten {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretNestedFlatten)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretPropertyMatch) DeepCopyInto(out *ExternalSecretPropertyMatch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretPropertyMatch.
+func (in *ExternalSecretPropertyMatch) DeepCopy() *ExternalSecretPropertyMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretPropertyMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretRewrite) DeepCopyInto(out *ExternalSecretRewrite) {
 	*out = *in
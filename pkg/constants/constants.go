@@ -35,8 +35,13 @@ const (
 	CallAzureKVDeleteKey         = "DeleteKey"
 	CallAzureKVImportKey         = "ImportKey"
 	CallAzureKVGetSecret         = "GetSecret"
+	CallAzureKVSetSecret         = "SetSecret"
+	CallAzureKVGetSecrets        = "GetSecrets"
+	CallAzureKVGetSecretVersions = "GetSecretVersions"
 	CallAzureKVDeleteSecret      = "DeleteSecret"
 	CallAzureKVGetCertificate    = "GetCertificate"
+	CallAzureKVGetCertificates   = "GetCertificates"
+	CallAzureKVGetCertPolicy     = "GetCertificatePolicy"
 	CallAzureKVDeleteCertificate = "DeleteCertificate"
 	CallAzureKVImportCertificate = "ImportCertificate"
 
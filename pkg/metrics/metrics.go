@@ -15,6 +15,8 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
@@ -24,6 +26,8 @@ import (
 const (
 	ExternalSecretSubsystem = "externalsecret"
 	providerAPICalls        = "provider_api_calls_count"
+	providerSecretAccess    = "provider_secret_access_count"
+	azureKVSubsystem        = "azure_kv"
 )
 
 var (
@@ -32,12 +36,48 @@ var (
 		Name:      providerAPICalls,
 		Help:      "Number of API calls towards the secret provider",
 	}, []string{"provider", "call", "status"})
+
+	secretAccessTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: ExternalSecretSubsystem,
+		Name:      providerSecretAccess,
+		Help:      "Number of secret values successfully read from the secret provider",
+	}, []string{"provider", "host", "object_type"})
+
+	azureKVRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "externalsecrets",
+		Subsystem: azureKVSubsystem,
+		Name:      "requests_total",
+		Help:      "Number of requests towards Azure Key Vault",
+	}, []string{"vault", "region", "object_type", "operation", "status"})
+
+	azureKVRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "externalsecrets",
+		Subsystem: azureKVSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of requests towards Azure Key Vault",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"vault", "region", "object_type", "operation", "status"})
 )
 
 func ObserveAPICall(provider, call string, err error) {
 	syncCallsTotal.WithLabelValues(provider, call, deriveStatus(err)).Inc()
 }
 
+// ObserveSecretAccess counts a successful secret read.
+// Secret names must not be passed as labels to keep the cardinality bounded.
+func ObserveSecretAccess(provider, host, objectType string) {
+	secretAccessTotal.WithLabelValues(provider, host, objectType).Inc()
+}
+
+// ObserveAzureKVRequest counts a request towards Azure Key Vault and records its latency.
+// The vault is its host and the region the one reported by the vault, empty if unknown.
+// Secret names must not be passed as labels.
+func ObserveAzureKVRequest(vault, region, objectType, operation string, err error, duration time.Duration) {
+	status := deriveStatus(err)
+	azureKVRequestsTotal.WithLabelValues(vault, region, objectType, operation, status).Inc()
+	azureKVRequestDuration.WithLabelValues(vault, region, objectType, operation, status).Observe(duration.Seconds())
+}
+
 func deriveStatus(err error) string {
 	if err != nil {
 		return constants.StatusError
@@ -46,5 +86,5 @@ func deriveStatus(err error) string {
 }
 
 func init() {
-	metrics.Registry.MustRegister(syncCallsTotal)
+	metrics.Registry.MustRegister(syncCallsTotal, secretAccessTotal, azureKVRequestsTotal, azureKVRequestDuration)
 }
@@ -0,0 +1,79 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// Creates the authorizers of the supported auth types. It is replaced in tests
+// to cover the auth type selection without acquiring tokens from Azure.
+type authorizerFactory interface {
+	// Acquires the managed identity authorizer once, authorizerForManagedIdentity retries it.
+	managedIdentity(ctx context.Context, a *Azure) (autorest.Authorizer, error)
+	servicePrincipal(ctx context.Context, a *Azure) (autorest.Authorizer, error)
+	workloadIdentity(ctx context.Context, a *Azure) (autorest.Authorizer, error)
+}
+
+// Creates the authorizers with the kvauth and adal constructors.
+type kvAuthorizerFactory struct{}
+
+func (kvAuthorizerFactory) managedIdentity(ctx context.Context, a *Azure) (autorest.Authorizer, error) {
+	return a.managedIdentityAuthorizer(ctx)
+}
+
+func (kvAuthorizerFactory) servicePrincipal(ctx context.Context, a *Azure) (autorest.Authorizer, error) {
+	return a.authorizerForServicePrincipal(ctx)
+}
+
+func (kvAuthorizerFactory) workloadIdentity(ctx context.Context, a *Azure) (autorest.Authorizer, error) {
+	return a.authorizerForWorkloadIdentity(ctx, tokenProviderWithClient(a.httpClient))
+}
+
+func (a *Azure) authorizerFactory() authorizerFactory {
+	if a.authorizers == nil {
+		return kvAuthorizerFactory{}
+	}
+	return a.authorizers
+}
+
+// Returns the authorizer of the configured auth type, which defaults to ServicePrincipal.
+// Rejected credentials and unreachable token endpoints are returned as the typed errors of Validate.
+func (a *Azure) newAuthorizer(ctx context.Context) (autorest.Authorizer, error) {
+	authorizer, err := a.authorizerForAuthType(ctx)
+	return authorizer, classifyVaultError(err)
+}
+
+func (a *Azure) authorizerForAuthType(ctx context.Context) (autorest.Authorizer, error) {
+	authType := esv1beta1.AzureServicePrincipal
+	if a.provider.AuthType != nil {
+		authType = *a.provider.AuthType
+	}
+	switch authType {
+	case esv1beta1.AzureManagedIdentity:
+		return a.authorizerForManagedIdentity(ctx)
+	case esv1beta1.AzureServicePrincipal:
+		return a.authorizerFactory().servicePrincipal(ctx, a)
+	case esv1beta1.AzureWorkloadIdentity:
+		return a.authorizerFactory().workloadIdentity(ctx, a)
+	default:
+		return nil, fmt.Errorf(errMissingAuthType)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const errFindObjectType = "find does not support object type %s"
+
+// Returns the CER contents of all certificates matching the find criteria, keyed by certificate name.
+func (a *Azure) getAllCertificates(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	certNames, err := a.findCertificateNames(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	certsMap, err := a.fetchAll(ctx, certNames, func(ctx context.Context, certName string) ([]byte, error) {
+		certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, certName, "")
+		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+		err = parseError(err)
+		if err != nil {
+			return nil, err
+		}
+		if certResp.Cer == nil {
+			return nil, errors.New(errMissingCertificate)
+		}
+		return *certResp.Cer, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.sanitizeKeys(stripFindPath(ref, certsMap))
+}
+
+// Returns the names of all certificates matching the name and tag filters of ref.
+// Disabled certificates are skipped, as are expired ones if SkipExpired is set.
+func (a *Azure) findCertificateNames(ctx context.Context, ref esv1beta1.ExternalSecretFind) ([]string, error) {
+	nameRe, err := compileFindRegExp(ref)
+	if err != nil {
+		return nil, err
+	}
+	certListIter, err := a.baseClient.GetCertificatesComplete(ctx, *a.provider.VaultURL, a.provider.MaxResults)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificates, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+
+	checkName := hasNameFilter(ref)
+	certNames := make([]string, 0)
+	seen := make(map[string]struct{})
+	for certListIter.NotDone() {
+		item := certListIter.Value()
+		if item.ID != nil && isEnabledCertificate(item) {
+			certName := path.Base(*item.ID)
+			_, dup := seen[certName]
+			ok := !dup &&
+				(!checkName || okByName(ref, nameRe, certName)) &&
+				okByPath(ref, certName) &&
+				matchesTags(ref.Tags, ref.TagMatch, item.Tags) &&
+				(!ref.SkipExpired || a.isCurrent(certificateValidity(item.Attributes))) &&
+				a.isAllowedSecret(certName)
+			if ok {
+				seen[certName] = struct{}{}
+				certNames = append(certNames, certName)
+			}
+		}
+
+		err = a.nextListPage(ctx, objectTypeCert, certListIter.NextWithContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return certNames, nil
+}
+
+// Certificates without attributes or an Enabled flag are treated as disabled, like secrets.
+func isEnabledCertificate(item keyvault.CertificateItem) bool {
+	return item.Attributes != nil && item.Attributes.Enabled != nil && *item.Attributes.Enabled
+}
+
+// Returns the validity period of a certificate in the form isCurrent expects.
+func certificateValidity(attrs *keyvault.CertificateAttributes) *keyvault.SecretAttributes {
+	if attrs == nil {
+		return nil
+	}
+	return &keyvault.SecretAttributes{NotBefore: attrs.NotBefore, Expires: attrs.Expires}
+}
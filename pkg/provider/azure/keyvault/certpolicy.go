@@ -0,0 +1,47 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+// Returns the current policy of a certificate as JSON, with the issuer, key properties and lifetime actions,
+// e.g. for rotation automation. A certificate without a policy returns an empty object.
+func (a *Azure) getCertificatePolicy(ctx context.Context, certName string) ([]byte, error) {
+	policy, err := a.baseClient.GetCertificatePolicy(ctx, *a.provider.VaultURL, certName)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertPolicy, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if isEmptyCertificatePolicy(policy) {
+		a.logger().Info("certificate has no policy, returning an empty object", "secret", certName)
+		return []byte("{}"), nil
+	}
+	// the read-only policy id is not marshaled
+	return json.Marshal(policy)
+}
+
+func isEmptyCertificatePolicy(policy keyvault.CertificatePolicy) bool {
+	return policy.KeyProperties == nil && policy.SecretProperties == nil && policy.X509CertificateProperties == nil &&
+		policy.LifetimeActions == nil && policy.IssuerParameters == nil && policy.Attributes == nil
+}
@@ -0,0 +1,105 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+)
+
+const (
+	errClaimsChallenge     = "could not refresh token for claims challenge: %w"
+	errTokenNotRefreshable = "token can not be refreshed"
+)
+
+// Matches the claims parameter of a WWW-Authenticate challenge.
+var claimsParam = regexp.MustCompile(`claims="([^"]*)"`)
+
+// Re-acquires the token used by the client after a Continuous Access Evaluation claims challenge.
+type claimsRefresher interface {
+	RefreshWithClaims(ctx context.Context, claims string) error
+}
+
+// Refreshes adal tokens on a claims challenge.
+// adal can not include the challenge claims in the token request, a fresh token
+// satisfies the challenges raised after revocation events, like a disabled account or changed credentials.
+type tokenClaimsRefresher struct {
+	token adal.RefresherWithContext
+}
+
+func (r tokenClaimsRefresher) RefreshWithClaims(ctx context.Context, _ string) error {
+	return r.token.RefreshWithContext(ctx)
+}
+
+// Returns a refresher for the token of authorizer, or nil if it does not use a refreshable token.
+// Workload identity tokens are requested with the challenge claims, adal tokens are refreshed without them.
+func newClaimsRefresher(authorizer autorest.Authorizer) claimsRefresher {
+	bearer, ok := authorizer.(*autorest.BearerAuthorizer)
+	if !ok {
+		return nil
+	}
+	if refresher, ok := bearer.TokenProvider().(claimsRefresher); ok {
+		return refresher
+	}
+	token, ok := bearer.TokenProvider().(adal.RefresherWithContext)
+	if !ok {
+		return nil
+	}
+	return tokenClaimsRefresher{token: token}
+}
+
+// Returns the decoded claims of a CAE claims challenge carried by err.
+func claimsChallenge(err error) (string, bool) {
+	var derr autorest.DetailedError
+	if !errors.As(err, &derr) || derr.StatusCode != http.StatusUnauthorized || derr.Response == nil {
+		return "", false
+	}
+	for _, header := range derr.Response.Header.Values("WWW-Authenticate") {
+		match := claimsParam.FindStringSubmatch(header)
+		if match == nil {
+			continue
+		}
+		claims, err := base64.StdEncoding.DecodeString(match[1])
+		if err != nil {
+			claims, err = base64.RawStdEncoding.DecodeString(match[1])
+		}
+		if err != nil {
+			return "", false
+		}
+		return string(claims), true
+	}
+	return "", false
+}
+
+// Runs fn and, if it fails with a claims challenge, re-acquires the token and runs it once more.
+func (a *Azure) retryOnClaimsChallenge(ctx context.Context, fn func() error) error {
+	err := fn()
+	claims, ok := claimsChallenge(err)
+	if !ok || a.claimsRefresher == nil {
+		return err
+	}
+	log.V(1).Info("received claims challenge, refreshing token")
+	if err := a.claimsRefresher.RefreshWithClaims(ctx, claims); err != nil {
+		return fmt.Errorf(errClaimsChallenge, err)
+	}
+	return fn()
+}
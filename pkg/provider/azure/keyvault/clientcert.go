@@ -0,0 +1,99 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	errClientSecretAndCertificate = "only one of clientSecret or clientCertificate can be set"
+	errDecodeClientCertificate    = "could not decode client certificate: %w"
+	errClientCertificateKey       = "client certificate must have an RSA private key"
+)
+
+// Builds the authorizer of a service principal authenticating with a client certificate.
+// The certificate is decoded in memory, it is never written to disk.
+func (a *Azure) authorizerForClientCertificate(ctx context.Context, clientID string, clusterScoped bool) (autorest.Authorizer, error) {
+	auth := a.provider.AuthSecretRef
+	data, err := a.secretKeyRefBytes(ctx, a.namespace, *auth.ClientCertificate, clusterScoped)
+	if err != nil {
+		return nil, err
+	}
+	password := ""
+	if auth.ClientCertificatePassword != nil {
+		password, err = a.secretKeyRef(ctx, a.namespace, *auth.ClientCertificatePassword, clusterScoped)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cert, key, err := decodeClientCertificate(data, password)
+	if err != nil {
+		return nil, err
+	}
+	tenantID := *a.provider.TenantID
+	return a.withAuthorizerTimeout(ctx, func(ctx context.Context) (autorest.Authorizer, error) {
+		oauthConfig, err := adal.NewOAuthConfig(AadEndpointForType(a.provider.EnvironmentType), tenantID)
+		if err != nil {
+			return nil, err
+		}
+		var callbacks []adal.TokenRefreshCallback
+		if isMultiTenant(tenantID) {
+			callbacks = append(callbacks, validateTenantClaim)
+		}
+		spToken, err := adal.NewServicePrincipalTokenFromCertificate(*oauthConfig, clientID, cert, key,
+			kvResourceForProviderConfig(a.provider.EnvironmentType), callbacks...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SPT from client certificate: %w", err)
+		}
+		a.setTokenSender(spToken)
+		if err := spToken.EnsureFreshWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get token from client certificate: %w", err)
+		}
+		return autorest.NewBearerAuthorizer(spToken), nil
+	})
+}
+
+// Decodes a client certificate given as PEM blocks or as a PKCS#12 archive.
+// Azure AD only accepts certificates with RSA keys.
+func decodeClientCertificate(data []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	var (
+		key  interface{}
+		cert *x509.Certificate
+		err  error
+	)
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		key, cert, _, err = decodePEMCertificate("clientCertificate", data)
+	} else {
+		key, cert, _, err = gopkcs12.DecodeChain(data, password)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf(errDecodeClientCertificate, err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New(errClientCertificateKey)
+	}
+	return cert, rsaKey, nil
+}
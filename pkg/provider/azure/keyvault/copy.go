@@ -0,0 +1,170 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	errCopyObjectType      = "copying object type %s is not supported"
+	errCopyReadSource      = "could not read source secret %s: %w"
+	errCopyReadDest        = "could not read destination secret %s: %w"
+	errCopyWrite           = "could not write destination secret %s: %w"
+	errCopyVaultNotAllowed = "destination vault %s is not the vault of the store or in its allowedVaults"
+)
+
+// CopySecretOptions configures the behavior of CopySecret.
+type CopySecretOptions struct {
+	// DryRun reports whether a copy would happen without writing to the destination.
+	DryRun bool
+	// SkipIfEqual skips the write if the destination already holds the same value, tags and content type.
+	SkipIfEqual bool
+}
+
+// CopySecret reads the secret referenced by srcRef from this client's vault
+// and writes it to dstName in the vault at dstVaultURL, preserving tags and content type.
+// The destination must be the vault of the store or one of its AllowedVaults, and is written
+// like PushSecret: only secrets managed by external-secrets and owned by this cluster are overwritten.
+// It returns true if the destination was (or, in dry-run mode, would have been) written.
+func (a *Azure) CopySecret(ctx context.Context, srcRef esv1beta1.ExternalSecretDataRemoteRef, dstVaultURL, dstName string, opts CopySecretOptions) (bool, error) {
+	objectType, secretName := a.resolveObjType(srcRef)
+	if objectType != defaultObjType {
+		return false, fmt.Errorf(errCopyObjectType, objectType)
+	}
+	dst, err := a.copyDestination(dstVaultURL)
+	if err != nil {
+		return false, err
+	}
+	if err := a.checkSecretName(secretName); err != nil {
+		return false, err
+	}
+	if err := dst.checkSecretName(dstName); err != nil {
+		return false, err
+	}
+	src, err := a.readCopySource(ctx, srcRef.Key, secretName, srcRef.Version)
+	if err != nil {
+		return false, fmt.Errorf(errCopyReadSource, secretName, err)
+	}
+
+	current, err := a.baseClient.GetSecret(ctx, dstVaultURL, dstName, "")
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	exists := err == nil
+	ok, err := canCreate(current.Tags, err)
+	if err != nil {
+		return false, fmt.Errorf(errCopyReadDest, dstName, err)
+	}
+	if !ok {
+		return false, nil
+	}
+	if err := dst.checkOwnership(dstName, current.Tags); err != nil {
+		return false, err
+	}
+	params := keyvault.SecretSetParameters{
+		Value:       src.Value,
+		Tags:        a.copyTags(src.Tags),
+		ContentType: src.ContentType,
+	}
+	if opts.SkipIfEqual && exists && isCurrentSecret(params, current) {
+		return false, nil
+	}
+	if opts.DryRun {
+		return true, nil
+	}
+	if src.Attributes != nil {
+		params.SecretAttributes = &keyvault.SecretAttributes{
+			Enabled:   src.Attributes.Enabled,
+			NotBefore: src.Attributes.NotBefore,
+			Expires:   src.Attributes.Expires,
+		}
+	}
+	_, err = a.baseClient.SetSecret(ctx, dstVaultURL, dstName, params)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVSetSecret, err)
+	if err != nil {
+		return false, fmt.Errorf(errCopyWrite, dstName, err)
+	}
+	return true, nil
+}
+
+// Returns the client of the destination vault, which must be a valid vault URL of the store's cloud
+// naming the vault of the store or one of its AllowedVaults.
+func (a *Azure) copyDestination(dstVaultURL string) (*Azure, error) {
+	dst := a.withVaultURL(dstVaultURL)
+	if err := dst.checkVaultURL(); err != nil {
+		return nil, err
+	}
+	if err := dst.checkVaultEnvironment(); err != nil {
+		return nil, err
+	}
+	host := dst.vaultHost()
+	if strings.EqualFold(host, a.vaultHost()) {
+		return dst, nil
+	}
+	vault, _, _ := strings.Cut(strings.ToLower(host), ".")
+	if a.names == nil || !a.names.vaults[vault] || !strings.EqualFold(host, vault+"."+a.vaultDNSSuffix()) {
+		return nil, fmt.Errorf(errCopyVaultNotAllowed, host)
+	}
+	return dst, nil
+}
+
+// Reads the source secret. Denied reads are cached like for GetSecret, so a missing
+// permission is not retried on every copy.
+func (a *Azure) readCopySource(ctx context.Context, key, secretName, version string) (keyvault.SecretBundle, error) {
+	forbiddenKey := a.forbiddenKey(key)
+	if until, ok := a.forbidden.get(forbiddenKey, a.now()); ok {
+		return keyvault.SecretBundle{}, fmt.Errorf(errForbiddenCached, key, until.Format(time.RFC3339))
+	}
+	src, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	if isForbidden(err) {
+		a.forbidden.add(forbiddenKey, a.now())
+	}
+	return src, parseError(err)
+}
+
+// Returns the tags of the source with the tags of a push, so the copy can be overwritten by later copies.
+func (a *Azure) copyTags(srcTags map[string]*string) map[string]*string {
+	tags := make(map[string]*string, len(srcTags)+2)
+	for k, v := range srcTags {
+		tags[k] = v
+	}
+	for k, v := range a.pushTags() {
+		tags[k] = v
+	}
+	return tags
+}
+
+// Reports whether the destination already holds the value, content type and tags to write.
+func isCurrentSecret(params keyvault.SecretSetParameters, current keyvault.SecretBundle) bool {
+	return equalStringPtr(params.Value, current.Value) && equalStringPtr(params.ContentType, current.ContentType) &&
+		reflect.DeepEqual(convertTags(params.Tags), convertTags(current.Tags))
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
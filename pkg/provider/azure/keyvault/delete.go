@@ -0,0 +1,85 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultDeleteConcurrency = 5
+
+	errDeleteNotConfirmed = "refusing to delete secrets without confirmation"
+	errDeleteNoFilter     = "refusing to delete secrets without a name or tag filter"
+)
+
+// DeleteAllSecretsOptions configures the behavior of DeleteAllSecrets.
+type DeleteAllSecretsOptions struct {
+	// DryRun only reports the secrets that would be deleted.
+	DryRun bool
+	// Confirm must be set to delete secrets when DryRun is not set.
+	Confirm bool
+	// Concurrency bounds the number of parallel deletes. Defaults to 5.
+	Concurrency int
+}
+
+// DeleteAllSecrets deletes every secret matching the find criteria which is managed by external-secrets.
+// It returns the names of the matching secrets and an aggregate of all per-secret errors.
+func (a *Azure) DeleteAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind, opts DeleteAllSecretsOptions) ([]string, error) {
+	if len(ref.Tags) == 0 && !hasNameFilter(ref) {
+		return nil, errors.New(errDeleteNoFilter)
+	}
+	if !opts.DryRun && !opts.Confirm {
+		return nil, errors.New(errDeleteNotConfirmed)
+	}
+	secretNames, err := a.findSecretNames(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return secretNames, nil
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultDeleteConcurrency
+	}
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	g := errgroup.Group{}
+	g.SetLimit(concurrency)
+	for _, secretName := range secretNames {
+		secretName := secretName
+		g.Go(func() error {
+			if err := a.deleteKeyVaultSecret(ctx, secretName); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return secretNames, utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,120 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"net/url"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+// ConfigDescription is the effective configuration of a client, with defaults applied.
+// It never contains secret material, references to credentials are only reported as configured or not.
+type ConfigDescription struct {
+	AuthType                 esv1beta1.AzureAuthType        `json:"authType"`
+	VaultURL                 string                         `json:"vaultUrl"`
+	EnvironmentType          esv1beta1.AzureEnvironmentType `json:"environmentType"`
+	APIVersion               string                         `json:"apiVersion"`
+	TenantID                 string                         `json:"tenantId,omitempty"`
+	IdentityID               string                         `json:"identityId,omitempty"`
+	IdentityResourceID       string                         `json:"identityResourceId,omitempty"`
+	MSIEndpoint              string                         `json:"msiEndpoint,omitempty"`
+	ClientIDConfigured       bool                           `json:"clientIdConfigured"`
+	ClientSecretConfigured   bool                           `json:"clientSecretConfigured"`
+	ClientCertConfigured     bool                           `json:"clientCertificateConfigured"`
+	ServiceAccountConfigured bool                           `json:"serviceAccountConfigured"`
+	AuthorizerTimeout        string                         `json:"authorizerTimeout"`
+	ValidateTimeout          string                         `json:"validateTimeout"`
+	IdleConnTimeout          string                         `json:"idleConnTimeout"`
+	ClientTimeout            string                         `json:"clientTimeout"`
+	MaxIdleConnsPerHost      int                            `json:"maxIdleConnsPerHost"`
+	ListRetries              int                            `json:"listRetries"`
+	FetchConcurrency         int                            `json:"fetchConcurrency"`
+	ForbiddenCacheTTL        string                         `json:"forbiddenCacheTTL"`
+	CacheTTL                 string                         `json:"cacheTTL,omitempty"`
+	MaxRetries               int                            `json:"maxRetries"`
+	RetryInterval            string                         `json:"retryInterval"`
+}
+
+// DescribeConfig returns the effective configuration of the client, so operators can confirm
+// what the store resolved to. Secret material and references to it are redacted.
+func (a *Azure) DescribeConfig() ConfigDescription {
+	p := a.provider
+	desc := ConfigDescription{
+		AuthType:          esv1beta1.AzureServicePrincipal,
+		EnvironmentType:   esv1beta1.AzureEnvironmentPublicCloud,
+		APIVersion:        a.Version(),
+		AuthorizerTimeout: defaultAuthorizerTimeout.String(),
+		ValidateTimeout:   validateTimeout.String(),
+		FetchConcurrency:  defaultFetchConcurrency,
+		ForbiddenCacheTTL: forbiddenCacheTTL.String(),
+	}
+	if p.AuthType != nil {
+		desc.AuthType = *p.AuthType
+	}
+	if p.VaultURL != nil {
+		desc.VaultURL = *p.VaultURL
+	}
+	if p.EnvironmentType != "" {
+		desc.EnvironmentType = p.EnvironmentType
+	}
+	if p.TenantID != nil {
+		desc.TenantID = *p.TenantID
+	}
+	if p.IdentityID != nil {
+		desc.IdentityID = *p.IdentityID
+	}
+	if p.IdentityResourceID != nil {
+		desc.IdentityResourceID = *p.IdentityResourceID
+	}
+	if p.MSIEndpoint != nil {
+		desc.MSIEndpoint = redactURL(*p.MSIEndpoint)
+	}
+	if p.AuthSecretRef != nil {
+		desc.ClientIDConfigured = p.AuthSecretRef.ClientID != nil
+		desc.ClientSecretConfigured = p.AuthSecretRef.ClientSecret != nil
+		desc.ClientCertConfigured = p.AuthSecretRef.ClientCertificate != nil
+	}
+	desc.ServiceAccountConfigured = p.ServiceAccountRef != nil
+	if p.AuthorizerTimeout != nil {
+		desc.AuthorizerTimeout = p.AuthorizerTimeout.Duration.String()
+	}
+	desc.ListRetries = listRetries(p)
+	if p.FetchConcurrency != nil {
+		desc.FetchConcurrency = int(*p.FetchConcurrency)
+	}
+	if p.CacheTTL != nil && p.CacheTTL.Duration > 0 {
+		desc.CacheTTL = p.CacheTTL.Duration.String()
+	}
+	transport := newTransport(p)
+	desc.IdleConnTimeout = transport.IdleConnTimeout.String()
+	desc.MaxIdleConnsPerHost = transport.MaxIdleConnsPerHost
+	desc.ClientTimeout = newHTTPClient(p).Timeout.String()
+	retrying := newRetryingClient(nil, p)
+	desc.MaxRetries = retrying.maxRetries
+	desc.RetryInterval = retrying.interval.String()
+	return desc
+}
+
+// Removes the user info and query of u, which may carry credentials.
+func redactURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	parsed.User = nil
+	parsed.RawQuery = ""
+	return parsed.String()
+}
@@ -0,0 +1,82 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	errClientClosed = "client is closed"
+)
+
+// Maximum time Close waits for in-flight calls, a variable so tests can shorten it.
+var drainTimeout = 30 * time.Second
+
+// Tracks in-flight calls. Calls are only added while the client is open,
+// so adding to the wait group can not race with Close waiting on it.
+type inflightCalls struct {
+	mu     sync.Mutex
+	wg     sync.WaitGroup
+	closed bool
+}
+
+// Registers a call, failing once the client is closed. done must be called when the call returns.
+func (c *inflightCalls) start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return errors.New(errClientClosed)
+	}
+	c.wg.Add(1)
+	return nil
+}
+
+func (c *inflightCalls) done() {
+	c.wg.Done()
+}
+
+// Rejects new calls and returns a channel closed once the in-flight calls finished.
+func (c *inflightCalls) close() <-chan struct{} {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+	drained := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(drained)
+	}()
+	return drained
+}
+
+// Close waits for in-flight GetSecret and GetAllSecrets calls to finish, up to
+// drainTimeout or until ctx is done, so they do not run against a torn down client.
+// Calls made after Close fail. The cached values are kept for the next client.
+func (a *Azure) Close(ctx context.Context) error {
+	drained := a.inflight.close()
+	timer := time.NewTimer(drainTimeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+	case <-timer.C:
+		log.Info("closing client with calls still in flight", "timeout", drainTimeout.String())
+	case <-ctx.Done():
+		log.Info("closing client with calls still in flight", "error", ctx.Err().Error())
+	}
+	return nil
+}
@@ -16,14 +16,17 @@ package fake
 import (
 	"context"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
 )
 
 type AzureMockClient struct {
 	getKey             func(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string) (result keyvault.KeyBundle, err error)
 	getSecret          func(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (result keyvault.SecretBundle, err error)
 	getSecretsComplete func(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.SecretListResultIterator, err error)
+	getSecretVersions  func(ctx context.Context, vaultBaseURL string, secretName string, maxresults *int32) (result keyvault.SecretListResultIterator, err error)
 	getCertificate     func(ctx context.Context, vaultBaseURL string, certificateName string, certificateVersion string) (result keyvault.CertificateBundle, err error)
+	getCertificates    func(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.CertificateListResultIterator, err error)
+	getCertPolicy      func(ctx context.Context, vaultBaseURL string, certificateName string) (result keyvault.CertificatePolicy, err error)
 	setSecret          func(ctx context.Context, vaultBaseURL string, secretName string, parameters keyvault.SecretSetParameters) (result keyvault.SecretBundle, err error)
 	importCertificate  func(ctx context.Context, vaultBaseURL string, certificateName string, parameters keyvault.CertificateImportParameters) (result keyvault.CertificateBundle, err error)
 	importKey          func(ctx context.Context, vaultBaseURL string, keyName string, parameters keyvault.KeyImportParameters) (result keyvault.KeyBundle, err error)
@@ -40,6 +43,14 @@ func (mc *AzureMockClient) GetCertificate(ctx context.Context, vaultBaseURL, cer
 	return mc.getCertificate(ctx, vaultBaseURL, certificateName, certificateVersion)
 }
 
+func (mc *AzureMockClient) GetCertificatesComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.CertificateListResultIterator, err error) {
+	return mc.getCertificates(ctx, vaultBaseURL, maxresults)
+}
+
+func (mc *AzureMockClient) GetCertificatePolicy(ctx context.Context, vaultBaseURL, certificateName string) (result keyvault.CertificatePolicy, err error) {
+	return mc.getCertPolicy(ctx, vaultBaseURL, certificateName)
+}
+
 func (mc *AzureMockClient) GetKey(ctx context.Context, vaultBaseURL, keyName, keyVersion string) (result keyvault.KeyBundle, err error) {
 	return mc.getKey(ctx, vaultBaseURL, keyName, keyVersion)
 }
@@ -48,6 +59,10 @@ func (mc *AzureMockClient) GetSecretsComplete(ctx context.Context, vaultBaseURL
 	return mc.getSecretsComplete(ctx, vaultBaseURL, maxresults)
 }
 
+func (mc *AzureMockClient) GetSecretVersionsComplete(ctx context.Context, vaultBaseURL, secretName string, maxresults *int32) (result keyvault.SecretListResultIterator, err error) {
+	return mc.getSecretVersions(ctx, vaultBaseURL, secretName, maxresults)
+}
+
 func (mc *AzureMockClient) SetSecret(ctx context.Context, vaultBaseURL, secretName string, parameters keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
 	return mc.setSecret(ctx, vaultBaseURL, secretName, parameters)
 }
@@ -80,6 +95,12 @@ func (mc *AzureMockClient) WithValue(_, _, _ string, apiOutput keyvault.SecretBu
 	}
 }
 
+func (mc *AzureMockClient) WithGetSecretFn(fn func(ctx context.Context, vaultBaseURL, secretName, secretVersion string) (keyvault.SecretBundle, error)) {
+	if mc != nil {
+		mc.getSecret = fn
+	}
+}
+
 func (mc *AzureMockClient) WithKey(_, _, _ string, apiOutput keyvault.KeyBundle, err error) {
 	if mc != nil {
 		mc.getKey = func(_ context.Context, _, _, _ string) (result keyvault.KeyBundle, retErr error) {
@@ -96,6 +117,28 @@ func (mc *AzureMockClient) WithCertificate(_, _, _ string, apiOutput keyvault.Ce
 	}
 }
 
+func (mc *AzureMockClient) WithGetCertificateFn(fn func(ctx context.Context, vaultBaseURL, certificateName, certificateVersion string) (keyvault.CertificateBundle, error)) {
+	if mc != nil {
+		mc.getCertificate = fn
+	}
+}
+
+func (mc *AzureMockClient) WithCertificateList(apiOutput keyvault.CertificateListResultIterator, err error) {
+	if mc != nil {
+		mc.getCertificates = func(_ context.Context, _ string, _ *int32) (keyvault.CertificateListResultIterator, error) {
+			return apiOutput, err
+		}
+	}
+}
+
+func (mc *AzureMockClient) WithCertificatePolicy(apiOutput keyvault.CertificatePolicy, err error) {
+	if mc != nil {
+		mc.getCertPolicy = func(_ context.Context, _, _ string) (keyvault.CertificatePolicy, error) {
+			return apiOutput, err
+		}
+	}
+}
+
 func (mc *AzureMockClient) WithImportCertificate(apiOutput keyvault.CertificateBundle, err error) {
 	if mc != nil {
 		mc.importCertificate = func(_ context.Context, _ string, _ string, _ keyvault.CertificateImportParameters) (keyvault.CertificateBundle, error) {
@@ -104,6 +147,12 @@ func (mc *AzureMockClient) WithImportCertificate(apiOutput keyvault.CertificateB
 	}
 }
 
+func (mc *AzureMockClient) WithImportCertificateFn(fn func(ctx context.Context, vaultBaseURL, certificateName string, parameters keyvault.CertificateImportParameters) (keyvault.CertificateBundle, error)) {
+	if mc != nil {
+		mc.importCertificate = fn
+	}
+}
+
 func (mc *AzureMockClient) WithImportKey(output keyvault.KeyBundle, err error) {
 	if mc != nil {
 		mc.importKey = func(_ context.Context, _ string, _ string, _ keyvault.KeyImportParameters) (keyvault.KeyBundle, error) {
@@ -112,6 +161,12 @@ func (mc *AzureMockClient) WithImportKey(output keyvault.KeyBundle, err error) {
 	}
 }
 
+func (mc *AzureMockClient) WithImportKeyFn(fn func(ctx context.Context, vaultBaseURL, keyName string, parameters keyvault.KeyImportParameters) (keyvault.KeyBundle, error)) {
+	if mc != nil {
+		mc.importKey = fn
+	}
+}
+
 func (mc *AzureMockClient) WithSetSecret(output keyvault.SecretBundle, err error) {
 	if mc != nil {
 		mc.setSecret = func(_ context.Context, _, _ string, _ keyvault.SecretSetParameters) (keyvault.SecretBundle, error) {
@@ -120,6 +175,12 @@ func (mc *AzureMockClient) WithSetSecret(output keyvault.SecretBundle, err error
 	}
 }
 
+func (mc *AzureMockClient) WithSetSecretFn(fn func(ctx context.Context, vaultBaseURL, secretName string, parameters keyvault.SecretSetParameters) (keyvault.SecretBundle, error)) {
+	if mc != nil {
+		mc.setSecret = fn
+	}
+}
+
 func (mc *AzureMockClient) WithDeleteSecret(output keyvault.DeletedSecretBundle, err error) {
 	if mc != nil {
 		mc.deleteSecret = func(_ context.Context, _, _ string) (keyvault.DeletedSecretBundle, error) {
@@ -128,6 +189,12 @@ func (mc *AzureMockClient) WithDeleteSecret(output keyvault.DeletedSecretBundle,
 	}
 }
 
+func (mc *AzureMockClient) WithDeleteSecretFn(fn func(ctx context.Context, vaultBaseURL, secretName string) (keyvault.DeletedSecretBundle, error)) {
+	if mc != nil {
+		mc.deleteSecret = fn
+	}
+}
+
 func (mc *AzureMockClient) WithDeleteCertificate(output keyvault.DeletedCertificateBundle, err error) {
 	if mc != nil {
 		mc.deleteCertificate = func(_ context.Context, _, _ string) (keyvault.DeletedCertificateBundle, error) {
@@ -151,3 +218,17 @@ func (mc *AzureMockClient) WithList(_ string, apiOutput keyvault.SecretListResul
 		}
 	}
 }
+
+func (mc *AzureMockClient) WithListFn(fn func(ctx context.Context, vaultBaseURL string, maxresults *int32) (keyvault.SecretListResultIterator, error)) {
+	if mc != nil {
+		mc.getSecretsComplete = fn
+	}
+}
+
+func (mc *AzureMockClient) WithSecretVersions(apiOutput keyvault.SecretListResultIterator, err error) {
+	if mc != nil {
+		mc.getSecretVersions = func(_ context.Context, _, _ string, _ *int32) (keyvault.SecretListResultIterator, error) {
+			return apiOutput, err
+		}
+	}
+}
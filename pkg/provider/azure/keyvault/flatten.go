@@ -0,0 +1,89 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tidwall/gjson"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultFlattenSeparator = "."
+
+	errFlattenCollision = "key %s is produced twice when flattening %s"
+)
+
+// Decodes the JSON object of a secret for GetSecretMap, flattening nested values if NestedFlatten is set.
+func decodeSecretMap(ref esv1beta1.ExternalSecretDataRemoteRef, data []byte) (map[string][]byte, error) {
+	if ref.NestedFlatten == nil {
+		return getSecretMapMap(ref.Key, data)
+	}
+	// reuse the validation and error messages of the flat decoding
+	if _, err := getSecretMapMap(ref.Key, data); err != nil {
+		return nil, err
+	}
+	separator := ref.NestedFlatten.Separator
+	if separator == "" {
+		separator = defaultFlattenSeparator
+	}
+	secretMap := make(map[string][]byte)
+	if err := flattenJSON(ref.Key, "", gjson.ParseBytes(data), separator, secretMap); err != nil {
+		return nil, err
+	}
+	return secretMap, nil
+}
+
+// Adds every leaf of value to out, keyed by its path joined with separator.
+// Arrays are keyed by index, empty objects and arrays are kept as leaves.
+func flattenJSON(secretName, prefix string, value gjson.Result, separator string, out map[string][]byte) error {
+	isContainer := value.IsObject() || value.IsArray()
+	if !isContainer || (prefix != "" && isEmptyContainer(value)) {
+		if _, exists := out[prefix]; exists {
+			return fmt.Errorf(errFlattenCollision, prefix, secretName)
+		}
+		if value.Type == gjson.String {
+			out[prefix] = []byte(value.Str)
+		} else {
+			out[prefix] = []byte(value.Raw)
+		}
+		return nil
+	}
+	var err error
+	index := 0
+	value.ForEach(func(k, v gjson.Result) bool {
+		key := k.String()
+		if value.IsArray() {
+			key = strconv.Itoa(index)
+			index++
+		}
+		if prefix != "" {
+			key = prefix + separator + key
+		}
+		err = flattenJSON(secretName, key, v, separator, out)
+		return err == nil
+	})
+	return err
+}
+
+func isEmptyContainer(value gjson.Result) bool {
+	if value.IsArray() {
+		return len(value.Array()) == 0
+	}
+	return len(value.Map()) == 0
+}
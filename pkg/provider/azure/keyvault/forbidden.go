@@ -0,0 +1,119 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	smmeta "github.com/external-secrets/external-secrets/apis/meta/v1"
+)
+
+const (
+	forbiddenCacheTTL = time.Minute
+
+	errForbiddenCached = "access to %s was denied by the vault, not retrying until %s"
+)
+
+// Shared across clients, so denied secrets are not retried on every reconcile.
+// Entries are scoped to the store and its credential by forbiddenKey.
+var sharedForbiddenCache = newForbiddenCache()
+
+// Remembers secrets the credential was denied access to.
+// Entries expire after forbiddenCacheTTL so a fixed RBAC grant is picked up.
+type forbiddenCache struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+}
+
+func newForbiddenCache() *forbiddenCache {
+	return &forbiddenCache{entries: make(map[string]time.Time)}
+}
+
+// Returns the time until which access to key is known to be denied.
+func (c *forbiddenCache) get(key string, now time.Time) (time.Time, bool) {
+	if c == nil {
+		return time.Time{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	until, ok := c.entries[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	if !now.Before(until) {
+		delete(c.entries, key)
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+func (c *forbiddenCache) add(key string, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = now.Add(forbiddenCacheTTL)
+}
+
+func isForbidden(err error) bool {
+	aerr := autorest.DetailedError{}
+	return errors.As(err, &aerr) && aerr.StatusCode == http.StatusForbidden
+}
+
+// Scopes the entry to the store and the identity it authenticates as, so a denial seen by
+// one store is not applied to another store reading the same vault with other credentials.
+func (a *Azure) forbiddenKey(key string) string {
+	store := ""
+	if a.store != nil {
+		store = storeKey(a.store)
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%s", store, a.namespace, a.authIdentity(), *a.provider.VaultURL, key)
+}
+
+func storeKey(store esv1beta1.GenericStore) string {
+	return fmt.Sprintf("%s/%s/%s", store.GetKind(), store.GetNamespace(), store.GetName())
+}
+
+// Describes the credential configured on the provider, without resolving any secret.
+func (a *Azure) authIdentity() string {
+	p := a.provider
+	parts := []string{
+		string(pointer.Deref(p.AuthType, esv1beta1.AzureServicePrincipal)),
+		pointer.Deref(p.TenantID, ""),
+		pointer.Deref(p.IdentityID, ""),
+		pointer.Deref(p.IdentityResourceID, ""),
+	}
+	if p.AuthSecretRef != nil {
+		for _, ref := range []*smmeta.SecretKeySelector{p.AuthSecretRef.ClientID, p.AuthSecretRef.ClientSecret, p.AuthSecretRef.ClientCertificate} {
+			if ref != nil {
+				parts = append(parts, fmt.Sprintf("%s/%s/%s", pointer.Deref(ref.Namespace, ""), ref.Name, ref.Key))
+			}
+		}
+	}
+	if p.ServiceAccountRef != nil {
+		parts = append(parts, fmt.Sprintf("%s/%s", pointer.Deref(p.ServiceAccountRef.Namespace, ""), p.ServiceAccountRef.Name))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,113 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+// Wraps a SecretClient to count the read requests towards the vault and record their latency.
+// Requests are observed on error paths too. Other calls are passed through.
+type instrumentedClient struct {
+	SecretClient
+	// Records the regions reported by the vault responses, nil to not record them.
+	regions *regionCache
+}
+
+func newInstrumentedClient(client SecretClient, regions *regionCache) *instrumentedClient {
+	return &instrumentedClient{SecretClient: client, regions: regions}
+}
+
+func (c *instrumentedClient) GetKey(ctx context.Context, vaultBaseURL, keyName, keyVersion string) (keyvault.KeyBundle, error) {
+	start := time.Now()
+	result, err := c.SecretClient.GetKey(ctx, vaultBaseURL, keyName, keyVersion)
+	c.observe(vaultBaseURL, objectTypeKey, constants.CallAzureKVGetKey, result.Response, err, start)
+	return result, err
+}
+
+func (c *instrumentedClient) GetSecret(ctx context.Context, vaultBaseURL, secretName, secretVersion string) (keyvault.SecretBundle, error) {
+	start := time.Now()
+	result, err := c.SecretClient.GetSecret(ctx, vaultBaseURL, secretName, secretVersion)
+	c.observe(vaultBaseURL, defaultObjType, constants.CallAzureKVGetSecret, result.Response, err, start)
+	return result, err
+}
+
+// Only the request of the first page is observed, the iterator fetches the next ones.
+func (c *instrumentedClient) GetSecretsComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (keyvault.SecretListResultIterator, error) {
+	start := time.Now()
+	result, err := c.SecretClient.GetSecretsComplete(ctx, vaultBaseURL, maxresults)
+	c.observe(vaultBaseURL, defaultObjType, constants.CallAzureKVGetSecrets, result.Response().Response, err, start)
+	return result, err
+}
+
+// Only the request of the first page is observed, the iterator fetches the next ones.
+func (c *instrumentedClient) GetSecretVersionsComplete(ctx context.Context, vaultBaseURL, secretName string, maxresults *int32) (keyvault.SecretListResultIterator, error) {
+	start := time.Now()
+	result, err := c.SecretClient.GetSecretVersionsComplete(ctx, vaultBaseURL, secretName, maxresults)
+	c.observe(vaultBaseURL, defaultObjType, constants.CallAzureKVGetSecretVersions, result.Response().Response, err, start)
+	return result, err
+}
+
+func (c *instrumentedClient) GetCertificate(ctx context.Context, vaultBaseURL, certificateName, certificateVersion string) (keyvault.CertificateBundle, error) {
+	start := time.Now()
+	result, err := c.SecretClient.GetCertificate(ctx, vaultBaseURL, certificateName, certificateVersion)
+	c.observe(vaultBaseURL, objectTypeCert, constants.CallAzureKVGetCertificate, result.Response, err, start)
+	return result, err
+}
+
+// Only the request of the first page is observed, the iterator fetches the next ones.
+func (c *instrumentedClient) GetCertificatesComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (keyvault.CertificateListResultIterator, error) {
+	start := time.Now()
+	result, err := c.SecretClient.GetCertificatesComplete(ctx, vaultBaseURL, maxresults)
+	c.observe(vaultBaseURL, objectTypeCert, constants.CallAzureKVGetCertificates, result.Response().Response, err, start)
+	return result, err
+}
+
+func (c *instrumentedClient) GetCertificatePolicy(ctx context.Context, vaultBaseURL, certificateName string) (keyvault.CertificatePolicy, error) {
+	start := time.Now()
+	result, err := c.SecretClient.GetCertificatePolicy(ctx, vaultBaseURL, certificateName)
+	c.observe(vaultBaseURL, objectTypeCert, constants.CallAzureKVGetCertPolicy, result.Response, err, start)
+	return result, err
+}
+
+// Records the request with the region reported in its response, which is remembered for vaultRegion.
+func (c *instrumentedClient) observe(vaultBaseURL, objectType, operation string, resp autorest.Response, err error, start time.Time) {
+	vault := urlHost(vaultBaseURL)
+	region := ""
+	if resp.Response != nil {
+		region = resp.Header.Get(headerKeyVaultRegion)
+	}
+	if region != "" {
+		c.regions.set(vault, region)
+	}
+	metrics.ObserveAzureKVRequest(vault, region, objectType, operation, err, time.Since(start))
+}
+
+// Returns the host of u, used as a low cardinality metric label.
+func urlHost(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
@@ -0,0 +1,73 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	defaultChecksumName = "{name}-sha256"
+
+	errIntegrityMismatch = "%w: %s does not match the checksum in %s"
+	errMissingChecksum   = "checksum secret %s of %s does not exist"
+	errGetChecksum       = "could not get checksum secret %s: %w"
+)
+
+// ErrIntegrityMismatch is returned when the SHA-256 of a secret value
+// does not match the checksum stored in its checksum secret.
+var ErrIntegrityMismatch = errors.New("secret integrity check failed")
+
+// verifyIntegrity compares the SHA-256 of value with the checksum secret of secretName, if IntegrityCheck is set.
+func (a *Azure) verifyIntegrity(ctx context.Context, secretName, value string) error {
+	check := a.provider.IntegrityCheck
+	if check == nil {
+		return nil
+	}
+	pattern := check.ChecksumName
+	if pattern == "" {
+		pattern = defaultChecksumName
+	}
+	checksumName := strings.ReplaceAll(pattern, "{name}", secretName)
+
+	checksumResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, checksumName, "")
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	err = parseError(err)
+	if errors.Is(err, esv1beta1.NoSecretErr) {
+		if check.RequireChecksum {
+			return fmt.Errorf(errMissingChecksum, checksumName, secretName)
+		}
+		log.V(1).Info("checksum secret does not exist, skipping integrity check", "secret", secretName, "checksum", checksumName)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(errGetChecksum, checksumName, err)
+	}
+
+	sum := sha256.Sum256([]byte(value))
+	if checksumResp.Value == nil || !strings.EqualFold(strings.TrimSpace(*checksumResp.Value), hex.EncodeToString(sum[:])) {
+		return fmt.Errorf(errIntegrityMismatch, ErrIntegrityMismatch, secretName, checksumName)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	errKeyPEMType      = "key %s has type %s, only RSA and EC keys can be converted to PEM"
+	errKeyPEMCurve     = "key %s uses the unsupported curve %s"
+	errKeyPEMComponent = "key %s has an invalid %s component"
+	errKeyPEMMissing   = "key %s has no key material"
+)
+
+// Returns the public key of a Key Vault key as PEM encoded PKIX (SPKI), for consumers that do not read JWKs.
+func (a *Azure) getKeyPEM(ctx context.Context, keyName, version string) ([]byte, error) {
+	keyResp, err := a.baseClient.GetKey(ctx, *a.provider.VaultURL, keyName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if keyResp.Key == nil {
+		return nil, fmt.Errorf(errKeyPEMMissing, keyName)
+	}
+	pub, err := jwkPublicKey(keyName, keyResp.Key)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// Builds the public key of an RSA or EC JWK, HSM protected keys are handled like software keys.
+func jwkPublicKey(keyName string, key *keyvault.JSONWebKey) (interface{}, error) {
+	switch strings.TrimSuffix(string(key.Kty), "-HSM") {
+	case string(keyvault.RSA):
+		n, err := jwkComponent(keyName, "n", key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := jwkComponent(keyName, "e", key.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf(errKeyPEMComponent, keyName, "e")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case string(keyvault.EC):
+		curve, err := jwkCurve(keyName, key.Crv)
+		if err != nil {
+			return nil, err
+		}
+		x, err := jwkComponent(keyName, "x", key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := jwkComponent(keyName, "y", key.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf(errKeyPEMComponent, keyName, "x, y")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf(errKeyPEMType, keyName, key.Kty)
+}
+
+func jwkCurve(keyName string, crv keyvault.JSONWebKeyCurveName) (elliptic.Curve, error) {
+	switch crv {
+	case keyvault.P256:
+		return elliptic.P256(), nil
+	case keyvault.P384:
+		return elliptic.P384(), nil
+	case keyvault.P521:
+		return elliptic.P521(), nil
+	}
+	return nil, fmt.Errorf(errKeyPEMCurve, keyName, crv)
+}
+
+// Decodes a base64url encoded big-endian integer of a JWK.
+func jwkComponent(keyName, name string, value *string) (*big.Int, error) {
+	if value == nil || *value == "" {
+		return nil, fmt.Errorf(errKeyPEMComponent, keyName, name)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(*value, "="))
+	if err != nil {
+		return nil, fmt.Errorf(errKeyPEMComponent, keyName, name)
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,257 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	b64 "encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/pavlo-v-chernykh/keystore-go/v4"
+	corev1 "k8s.io/api/core/v1"
+	gopkcs12 "software.sslmate.com/src/go-pkcs12"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	contentTypePKCS12 = "application/x-pkcs12"
+	contentTypePEM    = "application/x-pem-file"
+
+	nginxFullChainKey  = "fullchain.pem"
+	nginxPrivateKeyKey = "privkey.pem"
+
+	errMissingKeystore         = "cert-keystore requires the keystore to be configured in the store"
+	errMissingKeystorePassword = "missing keystore passwordSecretRef name or key"
+	errInvalidKeystorePassword = "invalid Keystore.PasswordSecretRef: %w"
+	errEmptyKeystorePassword   = "keystore password must not be empty"
+	errKeystoreFormat          = "keystore format %s is not supported"
+	errKeystoreContentType     = "certificate %s has unsupported content type %q"
+	errKeystoreDecode          = "could not decode certificate %s: %w"
+	errKeystoreMissingKey      = "certificate %s has no exportable private key"
+	errKeystoreEncode          = "could not encode keystore for certificate %s: %w"
+)
+
+// Returns the certificate and its private key packaged as a password protected keystore.
+// The private key is read from the secret backing the Key Vault certificate,
+// which requires the certificate to be created with an exportable key.
+func (a *Azure) getCertificateKeystore(ctx context.Context, certName, version string) ([]byte, error) {
+	ks := a.provider.Keystore
+	if ks == nil {
+		return nil, errors.New(errMissingKeystore)
+	}
+	password, err := a.keystorePassword(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, certName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	key, cert, caCerts, err := decodeCertificateSecret(certName, secretResp.ContentType, secretResp.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []byte
+	switch ks.Format {
+	case esv1beta1.AzureKVKeystorePKCS12, "":
+		out, err = gopkcs12.Modern.Encode(key, cert, caCerts, password)
+	case esv1beta1.AzureKVKeystoreJKS:
+		alias := ks.Alias
+		if alias == "" {
+			alias = certName
+		}
+		out, err = a.encodeJKS(alias, key, append([]*x509.Certificate{cert}, caCerts...), password)
+	default:
+		return nil, fmt.Errorf(errKeystoreFormat, ks.Format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(errKeystoreEncode, certName, err)
+	}
+	return out, nil
+}
+
+// Returns the certificate chain and its private key as PEM files named like the ones nginx
+// and Let's Encrypt tooling expect: fullchain.pem with the leaf and intermediate certificates,
+// and privkey.pem with the PKCS#8 encoded private key.
+func (a *Azure) getCertificateNginxBundle(ctx context.Context, certName, version string) (map[string][]byte, error) {
+	chain, key, err := a.getCertificatePEM(ctx, certName, version)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		nginxFullChainKey:  chain,
+		nginxPrivateKeyKey: key,
+	}, nil
+}
+
+// Returns the certificate chain followed by its private key as a single PEM bundle.
+func (a *Azure) getCertificatePEMBundle(ctx context.Context, certName, version string) ([]byte, error) {
+	chain, key, err := a.getCertificatePEM(ctx, certName, version)
+	if err != nil {
+		return nil, err
+	}
+	return append(chain, key...), nil
+}
+
+// Returns the certificate chain and its private key like a kubernetes.io/tls secret.
+func (a *Azure) getCertificateTLSMap(ctx context.Context, certName, version string) (map[string][]byte, error) {
+	chain, key, err := a.getCertificatePEM(ctx, certName, version)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		corev1.TLSCertKey:       chain,
+		corev1.TLSPrivateKeyKey: key,
+	}, nil
+}
+
+// Returns the PEM encoded certificate chain, leaf first, and the PEM encoded PKCS#8 private key
+// read from the secret backing the Key Vault certificate.
+func (a *Azure) getCertificatePEM(ctx context.Context, certName, version string) ([]byte, []byte, error) {
+	if err := a.checkSecretName(certName); err != nil {
+		return nil, nil, err
+	}
+	secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, certName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, cert, caCerts, err := decodeCertificateSecret(certName, secretResp.ContentType, secretResp.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf(errKeystoreEncode, certName, err)
+	}
+	var chain bytes.Buffer
+	for _, c := range append([]*x509.Certificate{cert}, caCerts...) {
+		if err := pem.Encode(&chain, &pem.Block{Type: "CERTIFICATE", Bytes: c.Raw}); err != nil {
+			return nil, nil, fmt.Errorf(errKeystoreEncode, certName, err)
+		}
+	}
+	return chain.Bytes(), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func (a *Azure) keystorePassword(ctx context.Context) (string, error) {
+	clusterScoped := a.store.GetKind() == esv1beta1.ClusterSecretStoreKind
+	password, err := a.secretKeyRef(ctx, a.namespace, a.provider.Keystore.PasswordSecretRef, clusterScoped)
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", errors.New(errEmptyKeystorePassword)
+	}
+	return password, nil
+}
+
+func (a *Azure) encodeJKS(alias string, key interface{}, chain []*x509.Certificate, password string) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	entry := keystore.PrivateKeyEntry{
+		CreationTime: a.now(),
+		PrivateKey:   der,
+	}
+	for _, cert := range chain {
+		entry.CertificateChain = append(entry.CertificateChain, keystore.Certificate{
+			Type:    "X509",
+			Content: cert.Raw,
+		})
+	}
+	ks := keystore.New()
+	if err := ks.SetPrivateKeyEntry(alias, entry, []byte(password)); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := ks.Store(&buf, []byte(password)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decodes the value of a secret backing a Key Vault certificate,
+// which is either a base64 encoded PKCS#12 without password or PEM blocks.
+func decodeCertificateSecret(certName string, contentType, value *string) (interface{}, *x509.Certificate, []*x509.Certificate, error) {
+	if value == nil {
+		return nil, nil, nil, fmt.Errorf(errKeystoreMissingKey, certName)
+	}
+	ct := ""
+	if contentType != nil {
+		ct = *contentType
+	}
+	switch ct {
+	case contentTypePKCS12:
+		data, err := b64.StdEncoding.DecodeString(*value)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf(errKeystoreDecode, certName, err)
+		}
+		key, cert, caCerts, err := gopkcs12.DecodeChain(data, "")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf(errKeystoreDecode, certName, err)
+		}
+		return key, cert, caCerts, nil
+	case contentTypePEM:
+		return decodePEMCertificate(certName, []byte(*value))
+	default:
+		return nil, nil, nil, fmt.Errorf(errKeystoreContentType, certName, ct)
+	}
+}
+
+func decodePEMCertificate(certName string, value []byte) (interface{}, *x509.Certificate, []*x509.Certificate, error) {
+	var (
+		key   interface{}
+		certs []*x509.Certificate
+	)
+	for {
+		block, rest := pem.Decode(value)
+		if block == nil {
+			break
+		}
+		value = rest
+		if block.Type == "CERTIFICATE" {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf(errKeystoreDecode, certName, err)
+			}
+			certs = append(certs, cert)
+			continue
+		}
+		k, err := getKeyFromValue(pem.EncodeToMemory(block))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf(errKeystoreDecode, certName, err)
+		}
+		key = k
+	}
+	if key == nil {
+		return nil, nil, nil, fmt.Errorf(errKeystoreMissingKey, certName)
+	}
+	if len(certs) == 0 {
+		return nil, nil, nil, errors.New(errMissingCertificate)
+	}
+	return key, certs[0], certs[1:], nil
+}
@@ -15,35 +15,50 @@ limitations under the License.
 package keyvault
 
 import (
+	"bytes"
 	"context"
+	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	b64 "encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/profiles/latest/keyvault/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	kvauth "github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
+	msalerrors "github.com/AzureAD/microsoft-authentication-library-for-go/apps/errors"
+	"github.com/go-logr/logr"
 	"github.com/lestrrat-go/jwx/jwk"
 	"github.com/tidwall/gjson"
 	"golang.org/x/crypto/pkcs12"
 	"golang.org/x/crypto/sha3"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
 	authv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	kcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/utils/clock"
 	pointer "k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlcfg "sigs.k8s.io/controller-runtime/pkg/client/config"
 
@@ -54,28 +69,102 @@ import (
 	"github.com/external-secrets/external-secrets/pkg/utils"
 )
 
+// sdkAPIVersion is the Key Vault API version of the imported SDK package, which is pinned
+// instead of following profiles/latest so the API version only changes on purpose.
+const sdkAPIVersion = "2016-10-01"
+
+// keyvaultAPIVersion is the Key Vault API version sent by the client, the one of the SDK package by default.
+// It can be selected at build time with
+// -ldflags "-X github.com/external-secrets/external-secrets/pkg/provider/azure/keyvault.keyvaultAPIVersion=7.4".
+var keyvaultAPIVersion = sdkAPIVersion
+
 const (
-	defaultObjType       = "secret"
-	objectTypeCert       = "cert"
-	objectTypeKey        = "key"
-	AzureDefaultAudience = "api://AzureADTokenExchange"
-	AnnotationClientID   = "azure.workload.identity/client-id"
-	AnnotationTenantID   = "azure.workload.identity/tenant-id"
-	managerLabel         = "external-secrets"
+	defaultObjType           = "secret"
+	objectTypeCert           = "cert"
+	objectTypeKey            = "key"
+	objectTypeCertStatus     = "cert-status"
+	objectTypeKeystore       = "cert-keystore"
+	objectTypeKeyInfo        = "key-info"
+	objectTypeCertCN         = "cert-cn"
+	objectTypeSecretID       = "secret-id"
+	objectTypeCertNginx      = "cert-nginx"
+	objectTypeSecretWithTags = "secret-with-tags"
+	objectTypeTemplate       = "template"
+	objectTypeCertPEM        = "cert-pem"
+	objectTypeCertKey        = "cert-key"
+	objectTypeCertTriple     = "cert-triple"
+	objectTypeKeyPEM         = "key-pem"
+	objectTypeCertPolicy     = "cert-policy"
+	objectTypeSecretVersions = "secret-versions"
+	versionLatest            = "latest"
+	AzureDefaultAudience     = "api://AzureADTokenExchange"
+	AnnotationClientID       = "azure.workload.identity/client-id"
+	AnnotationTenantID       = "azure.workload.identity/tenant-id"
+	managerLabel             = "external-secrets"
+	ownerTag                 = "owner"
+	tenantCommon             = "common"
+	tenantOrganizations      = "organizations"
+
+	defaultMaxIdleConnsPerHost = 10
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultClientTimeout       = 30 * time.Second
+	validateTimeout            = 15 * time.Second
+	defaultAuthorizerTimeout   = 30 * time.Second
+	defaultMSIRetries          = 3
+	defaultMSIRetryInterval    = time.Second
+	propagationRetries         = 3
+	defaultFetchConcurrency    = 5
+	maxListPageSize            = 25
 
 	errUnexpectedStoreSpec   = "unexpected store spec"
 	errMissingAuthType       = "cannot initialize Azure Client: no valid authType was specified"
 	errPropNotExist          = "property %s does not exist in key %s"
+	errFormatPropNotExist    = "properties %s referenced by format do not exist in key %s"
+	errInvalidPropertyMatch  = "invalid propertyMatch regexp %q: %w"
+	errInvalidFindRegExp     = "invalid find name regexp %q: %w"
+	errNoPropertyMatch       = "no property of key %s matches %q"
+	errNotJSONObject         = "value of %s is not a JSON object"
+	errSecretNotAllowed      = "secret %s is not in the store's list of allowed secrets"
+	errNameMismatch          = "secret %s does not match the store's name pattern %s"
+	errSecretTooOld          = "%w: %s was last updated %s ago"
+	errCertificateNameGuard  = "a certificate named %s exists, use cert/%s to read the certificate"
+	errValidateAs            = "value of %s is not a valid %s: %w"
+	errUnknownValidateAs     = "unknown validation format %s"
+	errNoPEMCertificate      = "no PEM encoded certificate found"
+	errNoRSAPrivateKey       = "no PEM encoded RSA private key found"
+	errNoURLHost             = "URL has no scheme or host"
+	errOwnedByOther          = "%s is owned by %s, set forceOwnership to take it over"
+	errReadOwnedByOther      = "%s is owned by %s, respectOwnership only allows reading secrets owned by %s"
+	errRecoveryLevelConflict = "vault recovery level %s conflicts with pushRecoverable=%t"
+	errProbeRecoveryLevel    = "could not probe vault recovery level: %w"
 	errTagNotExist           = "tag %s does not exist"
 	errUnknownObjectType     = "unknown Azure Keyvault object Type for %s"
+	errPushObjectType        = "push not supported for object type %s"
 	errUnmarshalJSONData     = "error unmarshalling json data: %w"
+	errDataFromJSONType      = "dataFrom requires the secret %s to be a JSON object, got %s"
+	errUnknownKeyTransform   = "unknown key transform %s"
+	errKeyTransformCollision = "keys %s and %s both transform to %s"
+	errKeySanitizeCollision  = "keys %s and %s both sanitize to %s"
+	errConvertYAML           = "could not convert key %s to YAML: %w"
+	errURLDecode             = "could not URL-decode the value of %s: %w"
+	errInvalidJSON           = "value is not valid JSON"
 	errDataFromCert          = "cannot get use dataFrom to get certificate secret"
 	errDataFromKey           = "cannot get use dataFrom to get key secret"
 	errMissingTenant         = "missing tenantID in store config"
 	errMissingSecretRef      = "missing secretRef in provider config"
 	errMissingClientIDSecret = "missing accessKeyID/secretAccessKey in store config"
+	errInvalidAccessToken    = "access token is not a valid JWT"
+	errDecodeAccessToken     = "could not decode access token claims: %w"
+	errMissingTenantClaim    = "access token does not contain a resolved tenant id"
 	errFindSecret            = "could not find secret %s/%s: %w"
 	errFindDataKey           = "no data for %q in secret '%s/%s'"
+	errClusterRefNamespace   = "namespace must be set on secretRef for ClusterSecretStore"
+	errMissingCertificate    = "certificate has no CER contents"
+	errParseCertificate      = "could not parse certificate: %w"
+	errMissingCommonName     = "certificate %s has no subject common name"
+	errMissingSecretID       = "secret %s has no identifier"
+	errKeyTooManyTokens      = "key %s has more than three tokens, expected <type>/<name>/<version>"
+	errEmptyVersionToken     = "key %s has an empty version token"
 
 	errInvalidStore              = "invalid store"
 	errInvalidStoreSpec          = "invalid store spec"
@@ -83,13 +172,81 @@ const (
 	errInvalidAzureProv          = "invalid azure keyvault provider"
 	errInvalidSecRefClientID     = "invalid AuthSecretRef.ClientID: %w"
 	errInvalidSecRefClientSecret = "invalid AuthSecretRef.ClientSecret: %w"
+	errInvalidSecRefClientCert   = "invalid AuthSecretRef.ClientCertificate: %w"
+	errInvalidSecRefCertPassword = "invalid AuthSecretRef.ClientCertificatePassword: %w"
 	errInvalidSARef              = "invalid ServiceAccountRef: %w"
+	errInvalidMSIEndpoint        = "invalid MSIEndpoint: %q is not a valid URL"
+	errIdentitySelectors         = "identityId and identityResourceId are mutually exclusive, set at most one of them"
+	errAuthorizerTimeout         = "timed out after %s acquiring the authorizer: %w"
+	errIdentityIDIgnored         = "%s is only used with the ManagedIdentity auth type and is ignored for auth type %s"
+	errVaultEnvironmentMismatch  = "vault URL %s belongs to %s, but environmentType is %s"
+	errInvalidVaultURL           = "invalid vault URL %q: %s, expected https://<name>.%s"
+	errInvalidAllowedSecret      = "invalid AllowedSecrets entry %q: %w"
+	errInvalidNamePattern        = "invalid NamePattern %q: %w"
+	errObjectTypeAliasShadows    = "invalid ObjectTypeAliases entry %q: aliases must not shadow a built-in object type"
+	errObjectTypeAliasUnknown    = "invalid ObjectTypeAliases entry %q: unknown object type %q"
+	errInvalidMaxResults         = "invalid MaxResults %d: must be between 1 and %d"
+	errInvalidFetchConcurrency   = "invalid FetchConcurrency %d: must be at least 1"
 
 	errMissingWorkloadEnvVars = "missing environment variables. AZURE_CLIENT_ID, AZURE_TENANT_ID and AZURE_FEDERATED_TOKEN_FILE must be set"
 	errReadTokenFile          = "unable to read token file %s: %w"
 	errMissingSAAnnotation    = "missing service account annotation: %s"
+
+	certStatusValid       = "valid"
+	certStatusExpired     = "expired"
+	certStatusNotYetValid = "not-yet-valid"
 )
 
+// ErrSecretNotYetActive is returned when RespectNotBefore is set
+// and the secret's NotBefore date lies in the future.
+var ErrSecretNotYetActive = errors.New("secret is not yet active")
+
+// ErrSecretTooOld is returned when the secret was last updated longer than MaxSecretAge ago.
+var ErrSecretTooOld = errors.New("secret is too old")
+
+// ErrEmptySecret is returned when AllowEmpty is false on the remote ref
+// and the fetched value is empty.
+var ErrEmptySecret = errors.New("secret value is empty")
+
+// VaultUnreachableError is returned by NewClient and Validate when the vault or the token endpoint
+// can not be reached, e.g. because of a DNS failure. The store is expected to become ready on retry.
+type VaultUnreachableError struct {
+	Err error
+}
+
+func (e VaultUnreachableError) Error() string {
+	return fmt.Sprintf("vault is unreachable: %v", e.Err)
+}
+
+func (e VaultUnreachableError) Unwrap() error {
+	return e.Err
+}
+
+// VaultUnauthorizedError is returned by NewClient and Validate when the configured credentials are
+// rejected, or are denied access to the vault.
+type VaultUnauthorizedError struct {
+	Err error
+}
+
+func (e VaultUnauthorizedError) Error() string {
+	return fmt.Sprintf("vault rejected the credentials: %v", e.Err)
+}
+
+func (e VaultUnauthorizedError) Unwrap() error {
+	return e.Err
+}
+
+// Delay between the fetches of a secret waiting for its ExpectedVersion.
+var propagationRetryInterval = time.Second
+
+// Initial delay before retrying a transient error while listing secrets, doubled on every retry.
+var listRetryInterval = 500 * time.Millisecond
+
+// Matches the characters not allowed in Kubernetes secret keys.
+var invalidKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+var log = ctrl.Log.WithName("provider").WithName("azure").WithName("keyvault")
+
 // https://github.com/external-secrets/external-secrets/issues/644
 var _ esv1beta1.SecretsClient = &Azure{}
 var _ esv1beta1.Provider = &Azure{}
@@ -99,7 +256,10 @@ type SecretClient interface {
 	GetKey(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string) (result keyvault.KeyBundle, err error)
 	GetSecret(ctx context.Context, vaultBaseURL string, secretName string, secretVersion string) (result keyvault.SecretBundle, err error)
 	GetSecretsComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.SecretListResultIterator, err error)
+	GetSecretVersionsComplete(ctx context.Context, vaultBaseURL string, secretName string, maxresults *int32) (result keyvault.SecretListResultIterator, err error)
 	GetCertificate(ctx context.Context, vaultBaseURL string, certificateName string, certificateVersion string) (result keyvault.CertificateBundle, err error)
+	GetCertificatesComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.CertificateListResultIterator, err error)
+	GetCertificatePolicy(ctx context.Context, vaultBaseURL string, certificateName string) (result keyvault.CertificatePolicy, err error)
 	SetSecret(ctx context.Context, vaultBaseURL string, secretName string, parameters keyvault.SecretSetParameters) (result keyvault.SecretBundle, err error)
 	ImportKey(ctx context.Context, vaultBaseURL string, keyName string, parameters keyvault.KeyImportParameters) (result keyvault.KeyBundle, err error)
 	ImportCertificate(ctx context.Context, vaultBaseURL string, certificateName string, parameters keyvault.CertificateImportParameters) (result keyvault.CertificateBundle, err error)
@@ -109,12 +269,27 @@ type SecretClient interface {
 }
 
 type Azure struct {
-	crClient   client.Client
-	kubeClient kcorev1.CoreV1Interface
-	store      esv1beta1.GenericStore
-	provider   *esv1beta1.AzureKVProvider
-	baseClient SecretClient
-	namespace  string
+	crClient     client.Client
+	kubeClient   kcorev1.CoreV1Interface
+	store        esv1beta1.GenericStore
+	provider     *esv1beta1.AzureKVProvider
+	baseClient   SecretClient
+	namespace    string
+	clock        clock.PassiveClock
+	forbidden    *forbiddenCache
+	regionLister vaultRegionLister
+	regions      *regionCache
+	// Re-acquires the token on claims challenges, nil if the authorizer has no refreshable token.
+	claimsRefresher claimsRefresher
+	// Creates the authorizer of the configured auth type, kvAuthorizerFactory if nil.
+	authorizers authorizerFactory
+	// Sends the vault and token requests, the default client if nil.
+	httpClient *http.Client
+	// Compiled by newClient, nil if the client has no name patterns.
+	names *namePatterns
+	// Tracks in-flight calls, Close waits for them to finish.
+	inflight inflightCalls
+	values   *valueCache
 }
 
 func init() {
@@ -123,6 +298,27 @@ func init() {
 	})
 }
 
+// Version returns the Key Vault API version used by the client, for diagnostics.
+func (a *Azure) Version() string {
+	return keyvaultAPIVersion
+}
+
+// Replaces the api-version set by the SDK package in every request with the selected one.
+func withAPIVersion(version string) autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			query := r.URL.Query()
+			query.Set("api-version", version)
+			r.URL.RawQuery = query.Encode()
+			return r, nil
+		})
+	}
+}
+
 // Capabilities return the provider supported capabilities (ReadOnly, WriteOnly, ReadWrite).
 func (a *Azure) Capabilities() esv1beta1.SecretStoreCapabilities {
 	return esv1beta1.SecretStoreReadWrite
@@ -152,7 +348,23 @@ func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Cl
 		store:      store,
 		namespace:  namespace,
 		provider:   provider,
+		clock:      clock.RealClock{},
+		forbidden:  sharedForbiddenCache,
+	}
+	if err := az.checkAuthConfig(); err != nil {
+		return nil, err
+	}
+	// a vault of another cloud is reported as such rather than as an invalid URL
+	if err := az.checkVaultEnvironment(); err != nil {
+		return nil, err
+	}
+	if err := az.checkVaultURL(); err != nil {
+		return nil, err
+	}
+	if az.names, err = compileNamePatterns(provider); err != nil {
+		return nil, err
 	}
+	az.values = newValueCache(sharedValues, provider.CacheTTL, az.valueScope())
 
 	// allow SecretStore controller validation to pass
 	// when using referent namespace.
@@ -162,21 +374,21 @@ func newClient(ctx context.Context, store esv1beta1.GenericStore, kube client.Cl
 		return az, nil
 	}
 
-	var authorizer autorest.Authorizer
-	switch *provider.AuthType {
-	case esv1beta1.AzureManagedIdentity:
-		authorizer, err = az.authorizerForManagedIdentity()
-	case esv1beta1.AzureServicePrincipal:
-		authorizer, err = az.authorizerForServicePrincipal(ctx)
-	case esv1beta1.AzureWorkloadIdentity:
-		authorizer, err = az.authorizerForWorkloadIdentity(ctx, NewTokenProvider)
-	default:
-		err = fmt.Errorf(errMissingAuthType)
-	}
+	// token requests share the connection pool of the vault requests
+	az.httpClient = newHTTPClient(provider)
+	authorizer, err := az.newAuthorizer(ctx)
 
 	cl := keyvault.New()
 	cl.Authorizer = authorizer
-	az.baseClient = &cl
+	cl.Sender = az.httpClient
+	if keyvaultAPIVersion != sdkAPIVersion {
+		cl.RequestInspector = withAPIVersion(keyvaultAPIVersion)
+	}
+	// every retried attempt is observed by the metrics
+	az.regions = newRegionCache()
+	az.baseClient = newRetryingClient(newInstrumentedClient(&cl, az.regions), provider)
+	az.regionLister = responseRegionLister{client: &cl}
+	az.claimsRefresher = newClaimsRefresher(authorizer)
 
 	return az, err
 }
@@ -206,15 +418,8 @@ func (a *Azure) ValidateStore(store esv1beta1.GenericStore) error {
 		return fmt.Errorf(errInvalidAzureProv)
 	}
 	if p.AuthSecretRef != nil {
-		if p.AuthSecretRef.ClientID != nil {
-			if err := utils.ValidateReferentSecretSelector(store, *p.AuthSecretRef.ClientID); err != nil {
-				return fmt.Errorf(errInvalidSecRefClientID, err)
-			}
-		}
-		if p.AuthSecretRef.ClientSecret != nil {
-			if err := utils.ValidateReferentSecretSelector(store, *p.AuthSecretRef.ClientSecret); err != nil {
-				return fmt.Errorf(errInvalidSecRefClientSecret, err)
-			}
+		if err := validateAuthSecretRef(store, p.AuthSecretRef); err != nil {
+			return err
 		}
 	}
 	if p.ServiceAccountRef != nil {
@@ -222,9 +427,127 @@ func (a *Azure) ValidateStore(store esv1beta1.GenericStore) error {
 			return fmt.Errorf(errInvalidSARef, err)
 		}
 	}
+	return validateProviderOptions(store, p)
+}
+
+func validateAuthSecretRef(store esv1beta1.GenericStore, auth *esv1beta1.AzureKVAuth) error {
+	if auth.ClientSecret != nil && auth.ClientCertificate != nil {
+		return errors.New(errClientSecretAndCertificate)
+	}
+	refs := []struct {
+		ref    *smmeta.SecretKeySelector
+		errFmt string
+	}{
+		{auth.ClientID, errInvalidSecRefClientID},
+		{auth.ClientSecret, errInvalidSecRefClientSecret},
+		{auth.ClientCertificate, errInvalidSecRefClientCert},
+		{auth.ClientCertificatePassword, errInvalidSecRefCertPassword},
+	}
+	for _, r := range refs {
+		if r.ref == nil {
+			continue
+		}
+		if err := utils.ValidateReferentSecretSelector(store, *r.ref); err != nil {
+			return fmt.Errorf(r.errFmt, err)
+		}
+	}
+	return nil
+}
+
+func validateProviderOptions(store esv1beta1.GenericStore, p *esv1beta1.AzureKVProvider) error {
+	if isSet(p.IdentityID) && isSet(p.IdentityResourceID) {
+		return errors.New(errIdentitySelectors)
+	}
+	if p.MSIEndpoint != nil {
+		u, err := url.Parse(*p.MSIEndpoint)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf(errInvalidMSIEndpoint, *p.MSIEndpoint)
+		}
+	}
+	if p.Keystore != nil {
+		if p.Keystore.PasswordSecretRef.Name == "" || p.Keystore.PasswordSecretRef.Key == "" {
+			return errors.New(errMissingKeystorePassword)
+		}
+		if err := utils.ValidateReferentSecretSelector(store, p.Keystore.PasswordSecretRef); err != nil {
+			return fmt.Errorf(errInvalidKeystorePassword, err)
+		}
+	}
+	if p.MaxResults != nil && (*p.MaxResults < 1 || *p.MaxResults > maxListPageSize) {
+		return fmt.Errorf(errInvalidMaxResults, *p.MaxResults, maxListPageSize)
+	}
+	if p.FetchConcurrency != nil && *p.FetchConcurrency < 1 {
+		return fmt.Errorf(errInvalidFetchConcurrency, *p.FetchConcurrency)
+	}
+	if err := validateNamePatterns(p); err != nil {
+		return err
+	}
+	if err := validateAllowedVaults(p); err != nil {
+		return err
+	}
+	return validateObjectTypeAliases(p)
+}
+
+// Aliases must not shadow a built-in object type and must resolve to one.
+func validateObjectTypeAliases(p *esv1beta1.AzureKVProvider) error {
+	for alias, objectType := range p.ObjectTypeAliases {
+		if isObjectType(alias) {
+			return fmt.Errorf(errObjectTypeAliasShadows, alias)
+		}
+		if !isObjectType(objectType) {
+			return fmt.Errorf(errObjectTypeAliasUnknown, alias, objectType)
+		}
+	}
 	return nil
 }
 
+func isObjectType(objectType string) bool {
+	switch objectType {
+	case defaultObjType, objectTypeCert, objectTypeKey, objectTypeCertStatus, objectTypeKeystore,
+		objectTypeKeyInfo, objectTypeCertCN, objectTypeSecretID, objectTypeCertNginx, objectTypeSecretWithTags,
+		objectTypeTemplate, objectTypeCertPEM, objectTypeCertKey, objectTypeCertTriple, objectTypeKeyPEM,
+		objectTypeCertPolicy, objectTypeSecretVersions:
+		return true
+	}
+	return false
+}
+
+func validateNamePatterns(p *esv1beta1.AzureKVProvider) error {
+	_, err := compileNamePatterns(p)
+	return err
+}
+
+// The name patterns and allowed vaults of a store, compiled once per client.
+type namePatterns struct {
+	// NamePattern anchored to the whole name, nil if not set.
+	name *regexp.Regexp
+	// AllowedSecrets anchored to the whole name.
+	allowed []*regexp.Regexp
+	// Lower-cased AllowedVaults.
+	vaults map[string]bool
+}
+
+func compileNamePatterns(p *esv1beta1.AzureKVProvider) (*namePatterns, error) {
+	patterns := &namePatterns{vaults: make(map[string]bool, len(p.AllowedVaults))}
+	if p.NamePattern != nil {
+		re, err := regexp.Compile("^(?:" + *p.NamePattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidNamePattern, *p.NamePattern, err)
+		}
+		patterns.name = re
+	}
+	for _, allowed := range p.AllowedSecrets {
+		re, err := regexp.Compile("^(?:" + allowed + ")$")
+		if err != nil {
+			return nil, fmt.Errorf(errInvalidAllowedSecret, allowed, err)
+		}
+		patterns.allowed = append(patterns.allowed, re)
+	}
+	for _, name := range p.AllowedVaults {
+		patterns.vaults[strings.ToLower(name)] = true
+	}
+	return patterns, nil
+}
+
 func canDelete(tags map[string]*string, err error) (bool, error) {
 	aerr := &autorest.DetailedError{}
 	conv := errors.As(err, aerr)
@@ -254,7 +577,8 @@ func (a *Azure) deleteKeyVaultKey(ctx context.Context, keyName string) error {
 	if ok {
 		_, err = a.baseClient.DeleteKey(ctx, *a.provider.VaultURL, keyName)
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVDeleteKey, err)
-		if err != nil {
+		// a 404 means a concurrent delete already removed it
+		if err != nil && !errors.Is(parseError(err), esv1beta1.NoSecretErr) {
 			return fmt.Errorf("error deleting key %v: %w", keyName, err)
 		}
 	}
@@ -271,7 +595,8 @@ func (a *Azure) deleteKeyVaultSecret(ctx context.Context, secretName string) err
 	if ok {
 		_, err = a.baseClient.DeleteSecret(ctx, *a.provider.VaultURL, secretName)
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVDeleteSecret, err)
-		if err != nil {
+		// a 404 means a concurrent delete already removed it
+		if err != nil && !errors.Is(parseError(err), esv1beta1.NoSecretErr) {
 			return fmt.Errorf("error deleting secret %v: %w", secretName, err)
 		}
 	}
@@ -288,7 +613,8 @@ func (a *Azure) deleteKeyVaultCertificate(ctx context.Context, certName string)
 	if ok {
 		_, err = a.baseClient.DeleteCertificate(ctx, *a.provider.VaultURL, certName)
 		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVDeleteCertificate, err)
-		if err != nil {
+		// a 404 means a concurrent delete already removed it
+		if err != nil && !errors.Is(parseError(err), esv1beta1.NoSecretErr) {
 			return fmt.Errorf("error deleting certificate %v: %w", certName, err)
 		}
 	}
@@ -296,7 +622,7 @@ func (a *Azure) deleteKeyVaultCertificate(ctx context.Context, certName string)
 }
 
 func (a *Azure) DeleteSecret(ctx context.Context, remoteRef esv1beta1.PushRemoteRef) error {
-	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: remoteRef.GetRemoteKey()})
+	objectType, secretName := a.resolveObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: remoteRef.GetRemoteKey()})
 	switch objectType {
 	case defaultObjType:
 		return a.deleteKeyVaultSecret(ctx, secretName)
@@ -375,6 +701,89 @@ func canCreate(tags map[string]*string, err error) (bool, error) {
 	return true, nil
 }
 
+// Refuses to overwrite an object whose owner tag names a different cluster,
+// unless ForceOwnership is set. Objects without an owner tag can always be taken over.
+func (a *Azure) checkOwnership(name string, tags map[string]*string) error {
+	if a.provider.OwnerID == nil || a.provider.ForceOwnership {
+		return nil
+	}
+	owner, ok := tags[ownerTag]
+	if !ok || owner == nil || *owner == *a.provider.OwnerID {
+		return nil
+	}
+	return fmt.Errorf(errOwnedByOther, name, *owner)
+}
+
+// Refuses to read an object whose owner tag names a different cluster, if RespectOwnership is set.
+// Objects without an owner tag can always be read.
+func (a *Azure) checkReadOwnership(name string, tags map[string]*string) error {
+	if a.provider.OwnerID == nil || !a.provider.RespectOwnership {
+		return nil
+	}
+	owner, ok := tags[ownerTag]
+	if !ok || owner == nil || *owner == *a.provider.OwnerID {
+		return nil
+	}
+	return fmt.Errorf(errReadOwnedByOther, name, *owner, *a.provider.OwnerID)
+}
+
+// Fails if the vault's recovery level conflicts with PushRecoverable.
+// The recovery level is taken from the existing object, or probed from any secret in the vault.
+// If it can not be detected the push is allowed.
+func (a *Azure) checkRecoveryLevel(ctx context.Context, level keyvault.DeletionRecoveryLevel) error {
+	if a.provider.PushRecoverable == nil {
+		return nil
+	}
+	if level == "" {
+		probed, err := a.probeRecoveryLevel(ctx)
+		if err != nil {
+			return fmt.Errorf(errProbeRecoveryLevel, err)
+		}
+		level = probed
+	}
+	if level == "" {
+		return nil
+	}
+	if isRecoverable(level) != *a.provider.PushRecoverable {
+		return fmt.Errorf(errRecoveryLevelConflict, level, *a.provider.PushRecoverable)
+	}
+	return nil
+}
+
+// Reports whether deleted objects can not be purged before the end of the retention interval.
+// Recoverable+Purgeable allows a privileged user to purge them, so it does not count as recoverable.
+func isRecoverable(level keyvault.DeletionRecoveryLevel) bool {
+	switch level {
+	case keyvault.Recoverable, keyvault.RecoverableProtectedSubscription:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *Azure) probeRecoveryLevel(ctx context.Context) (keyvault.DeletionRecoveryLevel, error) {
+	iter, err := a.baseClient.GetSecretsComplete(ctx, *a.provider.VaultURL, pointer.To(int32(1)))
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecrets, err)
+	if err != nil {
+		return "", err
+	}
+	if !iter.NotDone() || iter.Value().Attributes == nil {
+		return "", nil
+	}
+	return iter.Value().Attributes.RecoveryLevel, nil
+}
+
+// Returns the tags set on every pushed object.
+func (a *Azure) pushTags() map[string]*string {
+	tags := map[string]*string{
+		"managed-by": pointer.To(managerLabel),
+	}
+	if a.provider.OwnerID != nil {
+		tags[ownerTag] = a.provider.OwnerID
+	}
+	return tags
+}
+
 func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value []byte) error {
 	secret, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, "")
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
@@ -385,21 +794,29 @@ func (a *Azure) setKeyVaultSecret(ctx context.Context, secretName string, value
 	if !ok {
 		return nil
 	}
+	if err = a.checkOwnership(secretName, secret.Tags); err != nil {
+		return err
+	}
+	var level keyvault.DeletionRecoveryLevel
+	if secret.Attributes != nil {
+		level = secret.Attributes.RecoveryLevel
+	}
+	if err = a.checkRecoveryLevel(ctx, level); err != nil {
+		return err
+	}
 	val := string(value)
 	if secret.Value != nil && val == *secret.Value {
 		return nil
 	}
 	secretParams := keyvault.SecretSetParameters{
 		Value: &val,
-		Tags: map[string]*string{
-			"managed-by": pointer.To(managerLabel),
-		},
+		Tags:  a.pushTags(),
 		SecretAttributes: &keyvault.SecretAttributes{
 			Enabled: pointer.To(true),
 		},
 	}
 	_, err = a.baseClient.SetSecret(ctx, *a.provider.VaultURL, secretName, secretParams)
-	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVSetSecret, err)
 	if err != nil {
 		return fmt.Errorf("could not set secret %v: %w", secretName, err)
 	}
@@ -421,15 +838,23 @@ func (a *Azure) setKeyVaultCertificate(ctx context.Context, secretName string, v
 	if !ok {
 		return nil
 	}
+	if err = a.checkOwnership(secretName, cert.Tags); err != nil {
+		return err
+	}
+	var level keyvault.DeletionRecoveryLevel
+	if cert.Attributes != nil {
+		level = cert.Attributes.RecoveryLevel
+	}
+	if err = a.checkRecoveryLevel(ctx, level); err != nil {
+		return err
+	}
 	b512 := sha3.Sum512(localCert.Raw)
 	if cert.Cer != nil && b512 == sha3.Sum512(*cert.Cer) {
 		return nil
 	}
 	params := keyvault.CertificateImportParameters{
 		Base64EncodedCertificate: &val,
-		Tags: map[string]*string{
-			"managed-by": pointer.To(managerLabel),
-		},
+		Tags:                     a.pushTags(),
 	}
 	_, err = a.baseClient.ImportCertificate(ctx, *a.provider.VaultURL, secretName, params)
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportCertificate, err)
@@ -477,15 +902,23 @@ func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []b
 	if !ok {
 		return nil
 	}
+	if err = a.checkOwnership(secretName, keyFromVault.Tags); err != nil {
+		return err
+	}
+	var level keyvault.DeletionRecoveryLevel
+	if keyFromVault.Attributes != nil {
+		level = keyFromVault.Attributes.RecoveryLevel
+	}
+	if err = a.checkRecoveryLevel(ctx, level); err != nil {
+		return err
+	}
 	if keyFromVault.Key != nil && equalKeys(azkey, *keyFromVault.Key) {
 		return nil
 	}
 	params := keyvault.KeyImportParameters{
 		Key:           &azkey,
 		KeyAttributes: &keyvault.KeyAttributes{},
-		Tags: map[string]*string{
-			"managed-by": pointer.To(managerLabel),
-		},
+		Tags:          a.pushTags(),
 	}
 	_, err = a.baseClient.ImportKey(ctx, *a.provider.VaultURL, secretName, params)
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVImportKey, err)
@@ -497,7 +930,7 @@ func (a *Azure) setKeyVaultKey(ctx context.Context, secretName string, value []b
 
 // PushSecret stores secrets into a Key vault instance.
 func (a *Azure) PushSecret(ctx context.Context, value []byte, remoteRef esv1beta1.PushRemoteRef) error {
-	objectType, secretName := getObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: remoteRef.GetRemoteKey()})
+	objectType, secretName := a.resolveObjType(esv1beta1.ExternalSecretDataRemoteRef{Key: remoteRef.GetRemoteKey()})
 	switch objectType {
 	case defaultObjType:
 		return a.setKeyVaultSecret(ctx, secretName, value)
@@ -506,48 +939,148 @@ func (a *Azure) PushSecret(ctx context.Context, value []byte, remoteRef esv1beta
 	case objectTypeKey:
 		return a.setKeyVaultKey(ctx, secretName, value)
 	default:
-		return fmt.Errorf("secret type %v not supported", objectType)
+		return fmt.Errorf(errPushObjectType, objectType)
 	}
 }
 
 // Implements store.Client.GetAllSecrets Interface.
 // Retrieves a map[string][]byte with the secret names as key and the secret itself as the calue.
 func (a *Azure) GetAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
-	basicClient := a.baseClient
-	secretsMap := make(map[string][]byte)
-	checkTags := len(ref.Tags) > 0
-	checkName := ref.Name != nil && len(ref.Name.RegExp) > 0
+	if err := a.inflight.start(); err != nil {
+		return nil, err
+	}
+	defer a.inflight.done()
+	objectType := ref.ObjectType
+	if objectType == "" {
+		objectType = defaultObjType
+	}
+	logger := a.logger().WithValues("type", objectType)
+	logger.V(1).Info("listing secrets")
+	var secretsMap map[string][]byte
+	err := a.retryOnClaimsChallenge(ctx, func() (err error) {
+		secretsMap, err = a.getAllSecrets(ctx, ref)
+		return err
+	})
+	if err != nil {
+		logger.Error(err, "could not list secrets")
+		return nil, err
+	}
+	logger.V(1).Info("listed secrets", "count", len(secretsMap))
+	return secretsMap, nil
+}
 
-	secretListIter, err := basicClient.GetSecretsComplete(ctx, *a.provider.VaultURL, nil)
-	err = parseError(err)
+func (a *Azure) getAllSecrets(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string][]byte, error) {
+	switch ref.ObjectType {
+	case "", defaultObjType:
+	case objectTypeCert:
+		return a.getAllCertificates(ctx, ref)
+	default:
+		return nil, fmt.Errorf(errFindObjectType, ref.ObjectType)
+	}
+	secretNames, err := a.findSecretNames(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	secretsMap, err := a.fetchAll(ctx, secretNames, func(ctx context.Context, secretName string) ([]byte, error) {
+		secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, "")
+		err = parseError(err)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(*secretResp.Value), nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return a.sanitizeKeys(stripFindPath(ref, secretsMap))
+}
 
-	for secretListIter.NotDone() {
-		secretList := secretListIter.Response().Value
-		for _, secret := range *secretList {
-			ok, secretName := isValidSecret(checkTags, checkName, ref, secret)
-			if !ok {
-				continue
+// fetchAll calls fetch for every name, at most FetchConcurrency at a time, and returns the values keyed by name.
+func (a *Azure) fetchAll(ctx context.Context, names []string, fetch func(ctx context.Context, name string) ([]byte, error)) (map[string][]byte, error) {
+	concurrency := defaultFetchConcurrency
+	if a.provider.FetchConcurrency != nil {
+		concurrency = int(*a.provider.FetchConcurrency)
+	}
+	// a limit of 0 blocks every fetch, stores not validated by the webhook fall back to one at a time
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var mu sync.Mutex
+	values := make(map[string][]byte)
+	// the first error cancels gctx, aborting the remaining fetches
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(concurrency)
+	for _, name := range names {
+		name := name
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
 			}
-
-			secretResp, err := basicClient.GetSecret(ctx, *a.provider.VaultURL, secretName, "")
-			err = parseError(err)
+			value, err := fetch(gctx, name)
 			if err != nil {
-				return nil, err
+				return err
 			}
+			mu.Lock()
+			values[name] = value
+			mu.Unlock()
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// findSecretNames returns the names of all secrets matching the find criteria.
+func (a *Azure) findSecretNames(ctx context.Context, ref esv1beta1.ExternalSecretFind) ([]string, error) {
+	items, err := a.findSecretItems(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	secretNames := make([]string, 0, len(items))
+	for _, item := range items {
+		secretNames = append(secretNames, path.Base(*item.ID))
+	}
+	return secretNames, nil
+}
+
+// findSecretItems returns the list items of all secrets matching the find criteria.
+func (a *Azure) findSecretItems(ctx context.Context, ref esv1beta1.ExternalSecretFind) ([]keyvault.SecretItem, error) {
+	checkTags := len(ref.Tags) > 0
+	checkName := hasNameFilter(ref)
+	nameRe, err := compileFindRegExp(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	secretListIter, err := a.baseClient.GetSecretsComplete(ctx, *a.provider.VaultURL, a.provider.MaxResults)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
 
-			secretValue := *secretResp.Value
-			secretsMap[secretName] = []byte(secretValue)
+	items := make([]keyvault.SecretItem, 0)
+	seen := make(map[string]struct{})
+	for secretListIter.NotDone() {
+		item := secretListIter.Value()
+		ok, secretName := isValidSecret(checkTags, checkName, ref, nameRe, item)
+		if ok && ref.SkipExpired && !a.isCurrent(item.Attributes) {
+			ok = false
+		}
+		if _, dup := seen[secretName]; ok && dup {
+			log.V(1).Info("skipping duplicate secret in list response", "secret", secretName)
+		} else if ok && a.isAllowedSecret(secretName) {
+			seen[secretName] = struct{}{}
+			items = append(items, item)
 		}
 
-		err = secretListIter.Next()
+		err = a.nextSecretPage(ctx, &secretListIter)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return secretsMap, nil
+	return items, nil
 }
 
 // Retrieves a tag value if specified and all tags in JSON format if not.
@@ -600,6 +1133,95 @@ func getProperty(secret, property, key string) ([]byte, error) {
 	return []byte(res.String()), nil
 }
 
+// Retrieves the value of the first top level key of a JSON object matching the regexp,
+// or all matching keys and their values as a JSON object.
+func getMatchingProperties(secret string, match *esv1beta1.ExternalSecretPropertyMatch, key string) ([]byte, error) {
+	re, err := regexp.Compile(match.RegExp)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidPropertyMatch, match.RegExp, err)
+	}
+	doc := gjson.Parse(secret)
+	if !doc.IsObject() {
+		return nil, fmt.Errorf(errNotJSONObject, key)
+	}
+	var first []byte
+	matches := make(map[string]json.RawMessage)
+	doc.ForEach(func(k, v gjson.Result) bool {
+		if !re.MatchString(k.String()) {
+			return true
+		}
+		if match.Mode != esv1beta1.ExternalSecretPropertyMatchAll {
+			first = []byte(v.String())
+			return false
+		}
+		matches[k.String()] = json.RawMessage(v.Raw)
+		return true
+	})
+	if match.Mode == esv1beta1.ExternalSecretPropertyMatchAll {
+		if len(matches) == 0 {
+			return nil, fmt.Errorf(errNoPropertyMatch, key, match.RegExp)
+		}
+		return json.Marshal(matches)
+	}
+	if first == nil {
+		return nil, fmt.Errorf(errNoPropertyMatch, key, match.RegExp)
+	}
+	return first, nil
+}
+
+// Fails if the secret name is not allowed by the store or does not match its NamePattern,
+// before any call to Azure is made.
+func (a *Azure) checkSecretName(secretName string) error {
+	if !a.isAllowedSecret(secretName) {
+		return fmt.Errorf(errSecretNotAllowed, secretName)
+	}
+	if a.names == nil || a.names.name == nil {
+		return nil
+	}
+	if !a.names.name.MatchString(secretName) {
+		return fmt.Errorf(errNameMismatch, secretName, *a.provider.NamePattern)
+	}
+	return nil
+}
+
+// Reports whether the secret name matches one of the store's AllowedSecrets.
+// A store without AllowedSecrets allows every secret.
+func (a *Azure) isAllowedSecret(secretName string) bool {
+	if len(a.provider.AllowedSecrets) == 0 {
+		return true
+	}
+	if a.names == nil {
+		return false
+	}
+	for _, allowed := range a.names.allowed {
+		if allowed.MatchString(secretName) {
+			return true
+		}
+	}
+	return false
+}
+
+var formatPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// Replaces each {path} placeholder in format with the property at that path,
+// returning an error naming every path that could not be resolved.
+func formatProperties(secret, format, key string) ([]byte, error) {
+	var missing []string
+	out := formatPlaceholder.ReplaceAllStringFunc(format, func(placeholder string) string {
+		propertyPath := placeholder[1 : len(placeholder)-1]
+		value, err := getProperty(secret, propertyPath, key)
+		if err != nil {
+			missing = append(missing, propertyPath)
+			return placeholder
+		}
+		return string(value)
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf(errFormatPropNotExist, strings.Join(missing, ", "), key)
+	}
+	return []byte(out), nil
+}
+
 func parseError(err error) error {
 	aerr := autorest.DetailedError{}
 	if errors.As(err, &aerr) && aerr.StatusCode == 404 {
@@ -612,57 +1234,756 @@ func parseError(err error) error {
 // Retrieves a secret/Key/Certificate/Tag with the secret name defined in ref.Name
 // The Object Type is defined as a prefix in the ref.Name , if no prefix is defined , we assume a secret is required.
 func (a *Azure) GetSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
-	objectType, secretName := getObjType(ref)
+	if err := a.inflight.start(); err != nil {
+		return nil, err
+	}
+	defer a.inflight.done()
+	az, ref, err := a.forRefVault(ref)
+	if err != nil {
+		return nil, err
+	}
+	if az != a {
+		return az.GetSecret(ctx, ref)
+	}
+	ref, err = normalizeVersion(ref)
+	if err != nil {
+		return nil, err
+	}
+	return a.getLoggedSecret(ctx, ref, a.getSecretValue)
+}
 
-	switch objectType {
-	case defaultObjType:
-		// returns a SecretBundle with the secret value
-		// https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#SecretBundle
-		secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, ref.Version)
-		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
-		err = parseError(err)
+// Reads the value of a normalized ref with fetch, see getSecret, and logs the outcome.
+func (a *Azure) getLoggedSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, fetch secretValueFetcher) ([]byte, error) {
+	objectType, secretName := a.resolveObjType(ref)
+	logger := a.logger().WithValues("type", objectType, "secret", secretName, "version", ref.Version)
+	value, err := a.getSecret(ctx, ref, fetch)
+	// a missing secret is expected with deletionPolicy, it is not logged as an error
+	if errors.Is(err, esv1beta1.NoSecretErr) {
+		logger.V(1).Info("secret not found")
+		return nil, err
+	}
+	if err != nil {
+		logger.Error(err, "could not fetch secret")
+		return nil, err
+	}
+	metrics.ObserveSecretAccess(constants.ProviderAzureKV, a.vaultHost(), objectType)
+	logger.V(1).Info("fetched secret", "secret", describeSecret(objectType, secretName, ref.Version, value))
+	return value, nil
+}
+
+// Fetches the value of a ref, getSecretValue unless the caller needs more than the value.
+type secretValueFetcher func(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error)
+
+// Fetches and post-processes the value of a normalized ref, see GetSecret.
+func (a *Azure) getSecret(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, fetch secretValueFetcher) ([]byte, error) {
+	var value []byte
+	var err error
+	err = a.retryOnClaimsChallenge(ctx, func() (err error) {
+		value, err = a.fetchSecretValue(ctx, ref, fetch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 && ref.AllowEmpty != nil && !*ref.AllowEmpty {
+		return nil, ErrEmptySecret
+	}
+	if ref.URLDecode {
+		decoded, err := url.QueryUnescape(string(value))
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf(errURLDecode, ref.Key, err)
 		}
-		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(secretResp.Tags, ref.Property)
+		value = []byte(decoded)
+	}
+	if ref.ValidateAs != "" {
+		if err := validateValue(value, ref.ValidateAs); err != nil {
+			return nil, fmt.Errorf(errValidateAs, ref.Key, ref.ValidateAs, err)
 		}
-		return getProperty(*secretResp.Value, ref.Property, ref.Key)
-	case objectTypeCert:
-		// returns a CertBundle. We return CER contents of x509 certificate
-		// see: https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#CertificateBundle
-		certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, secretName, ref.Version)
-		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
-		err = parseError(err)
+	}
+	switch ref.OutputFormat {
+	case esv1beta1.ExternalSecretOutputFormatYAML:
+		value, err = jsonToYAML(value)
+		if err != nil {
+			return nil, fmt.Errorf(errConvertYAML, ref.Key, err)
+		}
+	case esv1beta1.ExternalSecretOutputFormatCanonicalJSON:
+		value, err = canonicalJSON(value)
 		if err != nil {
 			return nil, err
 		}
-		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(certResp.Tags, ref.Property)
+	}
+	return value, nil
+}
+
+// Converts a JSON document to block style YAML, keeping the order of object keys.
+func jsonToYAML(data []byte) ([]byte, error) {
+	if !json.Valid(data) {
+		return nil, errors.New(errInvalidJSON)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	resetYAMLStyle(&doc)
+	return yaml.Marshal(&doc)
+}
+
+// Checks that a secret value conforms to the format declared by ValidateAs.
+func validateValue(value []byte, format esv1beta1.ExternalSecretValidateAs) error {
+	switch format {
+	case esv1beta1.ExternalSecretValidateAsPEMCertificate:
+		block, _ := pem.Decode(value)
+		if block == nil || block.Type != "CERTIFICATE" {
+			return errors.New(errNoPEMCertificate)
+		}
+		_, err := x509.ParseCertificate(block.Bytes)
+		return err
+	case esv1beta1.ExternalSecretValidateAsRSAPrivateKey:
+		return validateRSAPrivateKey(value)
+	case esv1beta1.ExternalSecretValidateAsJSON:
+		if !json.Valid(value) {
+			return errors.New(errInvalidJSON)
+		}
+		return nil
+	case esv1beta1.ExternalSecretValidateAsURL:
+		u, err := url.ParseRequestURI(string(value))
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			// the url.Error quotes the value
+			return uerr.Err
+		}
+		if err != nil {
+			return err
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return errors.New(errNoURLHost)
+		}
+		return nil
+	default:
+		return fmt.Errorf(errUnknownValidateAs, format)
+	}
+}
+
+func validateRSAPrivateKey(value []byte) error {
+	block, _ := pem.Decode(value)
+	if block == nil {
+		return errors.New(errNoRSAPrivateKey)
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		_, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		return err
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return err
+		}
+		if _, ok := key.(*rsa.PrivateKey); !ok {
+			return errors.New(errNoRSAPrivateKey)
 		}
-		return *certResp.Cer, nil
+		return nil
+	default:
+		return errors.New(errNoRSAPrivateKey)
+	}
+}
+
+// Re-encodes a JSON document with sorted object keys and without insignificant whitespace,
+// so equivalent documents produce identical output. Values which are not valid JSON are returned as is.
+func canonicalJSON(data []byte) ([]byte, error) {
+	if !json.Valid(data) {
+		return data, nil
+	}
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Drops the flow and quoting styles yaml assigns to nodes decoded from JSON.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
+
+// Moves a version token of the key, like secret/<name>/<version>, to the version of the ref
+// unless it is already set, and translates the latest version keyword to the empty version,
+// which Azure resolves to the latest version.
+func normalizeVersion(ref esv1beta1.ExternalSecretDataRemoteRef) (esv1beta1.ExternalSecretDataRemoteRef, error) {
+	tokens := strings.Split(ref.Key, "/")
+	switch {
+	case len(tokens) > 3:
+		return ref, fmt.Errorf(errKeyTooManyTokens, ref.Key)
+	case len(tokens) == 3 && tokens[2] == "":
+		return ref, fmt.Errorf(errEmptyVersionToken, ref.Key)
+	case len(tokens) == 3:
+		if ref.Version == "" {
+			ref.Version = tokens[2]
+		}
+		ref.Key = tokens[0] + "/" + tokens[1]
+	}
+	if ref.Version == versionLatest {
+		ref.Version = ""
+	}
+	return ref, nil
+}
+
+// Fetches the secret value, failing fast for secrets recently denied by the vault.
+// Returns the DefaultValue of the ref if the secret does not exist.
+func (a *Azure) fetchSecretValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, fetch secretValueFetcher) ([]byte, error) {
+	forbiddenKey := a.forbiddenKey(ref.Key)
+	if until, ok := a.forbidden.get(forbiddenKey, a.now()); ok {
+		return nil, fmt.Errorf(errForbiddenCached, ref.Key, until.Format(time.RFC3339))
+	}
+	value, err := fetch(ctx, ref)
+	if isForbidden(err) {
+		a.forbidden.add(forbiddenKey, a.now())
+	}
+	if ref.DefaultValue != nil && errors.Is(err, esv1beta1.NoSecretErr) {
+		log.V(1).Info("secret not found, using the default value", "key", ref.Key)
+		return []byte(*ref.DefaultValue), nil
+	}
+	return value, err
+}
+
+// GetSecretReader returns the value of the secret referenced by ref as a stream, so callers can process
+// large values incrementally. Key Vault returns the whole value at once, the reader wraps it.
+func (a *Azure) GetSecretReader(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (io.ReadCloser, error) {
+	value, err := a.GetSecret(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(value)), nil
+}
+
+// Returns a placeholder for a secret value which is safe to log.
+// Secret values must never be logged or formatted without it.
+func redact(value []byte) string {
+	return fmt.Sprintf("*** (%d bytes)", len(value))
+}
+
+// Maximum length of a secret description, longer names are truncated.
+const maxSecretDescription = 256
+
+// Returns a description of a fetched secret which is safe to use in events
+// and logs. It contains the object type, name, version and size but never the value.
+func describeSecret(objectType, name, version string, value []byte) string {
+	if version == "" {
+		version = "latest"
+	}
+	desc := fmt.Sprintf("%s/%s (version %s): %s", objectType, name, version, redact(value))
+	if len(desc) > maxSecretDescription {
+		desc = desc[:maxSecretDescription-3] + "..."
+	}
+	return desc
+}
+
+// Returns the package logger with the vault as context. Secret values must never be logged,
+// use describeSecret to log a redacted description.
+func (a *Azure) logger() logr.Logger {
+	return log.WithValues("vault", a.vaultHost())
+}
+
+// Returns the host of the vault URL, used as a low cardinality metric label.
+func (a *Azure) vaultHost() string {
+	return urlHost(*a.provider.VaultURL)
+}
+
+func (a *Azure) getSecretValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+	objectType, secretName := a.resolveObjType(ref)
+	if thumbprint, ok := parseCertThumbprint(secretName); ok && objectType == objectTypeCert {
+		// the name is checked once the thumbprint is resolved
+		return a.getCertificateByThumbprint(ctx, thumbprint)
+	}
+	if err := a.checkSecretName(secretName); err != nil {
+		return nil, err
+	}
+
+	switch objectType {
+	case defaultObjType:
+		// returns a SecretBundle with the secret value
+		// https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#SecretBundle
+		return a.getKeyVaultSecretValue(ctx, ref, secretName)
+	case objectTypeCert:
+		// returns a CertBundle. We return CER contents of x509 certificate
+		// see: https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#CertificateBundle
+		return a.getCertificateValue(ctx, ref, secretName)
 	case objectTypeKey:
 		// returns a KeyBundle that contains a jwk
 		// azure kv returns only public keys
 		// see: https://pkg.go.dev/github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault#KeyBundle
-		keyResp, err := a.baseClient.GetKey(ctx, *a.provider.VaultURL, secretName, ref.Version)
-		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
-		err = parseError(err)
+		return a.getKeyValue(ctx, ref, secretName)
+	case objectTypeCertStatus:
+		// returns the validity status of the x509 certificate
+		cert, err := a.getX509Certificate(ctx, secretName, ref.Version)
 		if err != nil {
 			return nil, err
 		}
-		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
-			return getSecretTag(keyResp.Tags, ref.Property)
-		}
-		return json.Marshal(keyResp.Key)
+		return a.certificateStatus(cert), nil
+	case objectTypeCertCN:
+		// returns the subject common name of the x509 certificate
+		return a.getCertificateCommonName(ctx, ref, secretName)
+	case objectTypeSecretID:
+		// returns the full identifier URL of the secret
+		return a.getSecretID(ctx, secretName, ref.Version)
+	case objectTypeSecretWithTags:
+		// returns a JSON object with the secret value and its tags
+		return a.getSecretWithTags(ctx, ref, secretName)
+	case objectTypeKeystore, objectTypeCertPEM, objectTypeCertKey:
+		// returns the private key of the certificate, with or without the chain
+		return a.getCertificateWithKey(ctx, objectType, secretName, ref.Version)
+	case objectTypeCertTriple:
+		// returns the identifiers of the certificate, its secret and key and the leaf certificate
+		return a.getCertificateTriple(ctx, secretName, ref.Version)
+	case objectTypeKeyPEM:
+		// returns the public key of the JWK as PEM encoded PKIX
+		return a.getKeyPEM(ctx, secretName, ref.Version)
+	case objectTypeSecretVersions:
+		// returns all versions of the secret with their attributes as JSON
+		return a.getSecretVersions(ctx, secretName)
+	case objectTypeCertPolicy:
+		// returns the policy of the certificate as JSON
+		return a.getCertificatePolicy(ctx, secretName)
+	case objectTypeKeyInfo:
+		// returns the key attributes, without the key material
+		return a.getKeyInfo(ctx, secretName, ref.Version)
+	case objectTypeTemplate:
+		// returns the template secret rendered with the data secret named by the property
+		return a.renderTemplate(ctx, ref, secretName)
 	}
 
 	return nil, fmt.Errorf(errUnknownObjectType, secretName)
 }
 
+// Returns the object types which are read from the secret backing a certificate.
+func (a *Azure) getCertificateWithKey(ctx context.Context, objectType, certName, version string) ([]byte, error) {
+	switch objectType {
+	case objectTypeKeystore:
+		// returns the certificate and its private key as a keystore
+		return a.getCertificateKeystore(ctx, certName, version)
+	case objectTypeCertPEM:
+		// returns the certificate chain and its private key as a single PEM bundle
+		return a.getCertificatePEMBundle(ctx, certName, version)
+	default:
+		// returns the private key of the certificate as PKCS#8 PEM
+		_, key, err := a.getCertificatePEM(ctx, certName, version)
+		return key, err
+	}
+}
+
+func (a *Azure) getKeyVaultSecretValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, secretName string) ([]byte, error) {
+	secretResp, err := a.readSecretBundle(ctx, ref, secretName)
+	if err != nil {
+		return nil, err
+	}
+	return a.secretBundleValue(ctx, ref, secretName, secretResp)
+}
+
+// Fetches the secret and applies the checks of the store to it.
+func (a *Azure) readSecretBundle(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, secretName string) (keyvault.SecretBundle, error) {
+	secretResp, err := a.getSecretBundle(ctx, ref, secretName)
+	if err != nil {
+		return keyvault.SecretBundle{}, err
+	}
+	if err := a.checkSecretBundle(ctx, ref, secretName, secretResp); err != nil {
+		return keyvault.SecretBundle{}, err
+	}
+	return secretResp, nil
+}
+
+// Returns the value of a fetched secret, or its tag with MetadataPolicy Fetch.
+func (a *Azure) secretBundleValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, secretName string, secretResp keyvault.SecretBundle) ([]byte, error) {
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		return getSecretTag(secretResp.Tags, ref.Property)
+	}
+	if err := a.verifyIntegrity(ctx, secretName, *secretResp.Value); err != nil {
+		return nil, err
+	}
+	value := *secretResp.Value
+	if ref.NormalizeLineEndings {
+		value = strings.ReplaceAll(value, "\r\n", "\n")
+	}
+	value = strings.TrimSuffix(strings.TrimPrefix(value, ref.StripPrefix), ref.StripSuffix)
+	if ref.Format != "" {
+		return formatProperties(value, ref.Format, ref.Key)
+	}
+	if ref.PropertyMatch != nil {
+		return getMatchingProperties(value, ref.PropertyMatch, ref.Key)
+	}
+	out, err := getProperty(value, ref.Property, ref.Key)
+	// getProperty only fails for properties which do not exist
+	if err != nil && ref.PropertyDefault != nil {
+		return []byte(*ref.PropertyDefault), nil
+	}
+	return out, err
+}
+
+// Applies the checks of the store to a fetched secret before its value or tags are returned.
+func (a *Azure) checkSecretBundle(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, secretName string, secretResp keyvault.SecretBundle) error {
+	if a.provider.RespectNotBefore && !a.isActive(secretResp.Attributes) {
+		return ErrSecretNotYetActive
+	}
+	if err := a.checkSecretAge(secretName, secretResp.Attributes); err != nil {
+		return err
+	}
+	if err := a.checkReadOwnership(secretName, secretResp.Tags); err != nil {
+		return err
+	}
+	if err := a.checkCertificateName(ctx, secretName); err != nil {
+		return err
+	}
+	if a.provider.CheckStaleVersion && ref.Version != "" {
+		a.warnIfStaleVersion(ctx, secretName, ref.Version, secretResp.Attributes)
+	}
+	return nil
+}
+
+func (a *Azure) getKeyValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, keyName string) ([]byte, error) {
+	keyResp, err := a.baseClient.GetKey(ctx, *a.provider.VaultURL, keyName, ref.Version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		return getSecretTag(keyResp.Tags, ref.Property)
+	}
+	return json.Marshal(keyResp.Key)
+}
+
+func (a *Azure) getCertificateValue(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, certName string) ([]byte, error) {
+	certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, certName, ref.Version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		return getSecretTag(certResp.Tags, ref.Property)
+	}
+	return *certResp.Cer, nil
+}
+
+// Returns the identifiers of the certificate and of the secret and key Key Vault created
+// along with it, with the leaf certificate as PEM, so they can be read with a single call.
+func (a *Azure) getCertificateTriple(ctx context.Context, certName, version string) ([]byte, error) {
+	certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, certName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if certResp.Cer == nil {
+		return nil, errors.New(errMissingCertificate)
+	}
+	triple := struct {
+		CertificateID string `json:"certificateId"`
+		SecretID      string `json:"secretId"`
+		KeyID         string `json:"keyId"`
+		Certificate   string `json:"certificate"`
+	}{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: *certResp.Cer})),
+	}
+	if certResp.ID != nil {
+		triple.CertificateID = *certResp.ID
+	}
+	if certResp.Sid != nil {
+		triple.SecretID = *certResp.Sid
+	}
+	if certResp.Kid != nil {
+		triple.KeyID = *certResp.Kid
+	}
+	return json.Marshal(triple)
+}
+
+func (a *Azure) getSecretWithTags(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, secretName string) ([]byte, error) {
+	secretResp, err := a.getSecretBundle(ctx, ref, secretName)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkSecretBundle(ctx, ref, secretName, secretResp); err != nil {
+		return nil, err
+	}
+	if secretResp.Value != nil {
+		if err := a.verifyIntegrity(ctx, secretName, *secretResp.Value); err != nil {
+			return nil, err
+		}
+	}
+	projection := struct {
+		Value string            `json:"value"`
+		Tags  map[string]string `json:"tags"`
+	}{
+		Tags: convertTags(secretResp.Tags),
+	}
+	if secretResp.Value != nil {
+		projection.Value = *secretResp.Value
+	}
+	return json.Marshal(projection)
+}
+
+func (a *Azure) getCertificateCommonName(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, certName string) ([]byte, error) {
+	cert, err := a.getX509Certificate(ctx, certName, ref.Version)
+	if err != nil {
+		return nil, err
+	}
+	if cert.Subject.CommonName == "" && ref.AllowEmpty != nil && !*ref.AllowEmpty {
+		return nil, fmt.Errorf(errMissingCommonName, certName)
+	}
+	return []byte(cert.Subject.CommonName), nil
+}
+
+func (a *Azure) getSecretID(ctx context.Context, secretName, version string) ([]byte, error) {
+	secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if secretResp.ID == nil {
+		return nil, fmt.Errorf(errMissingSecretID, secretName)
+	}
+	return []byte(*secretResp.ID), nil
+}
+
+// Fetches the secret bundle. When the latest version is requested and ExpectedVersion is set,
+// the fetch is retried a few times until the expected version has propagated.
+// The last fetched bundle is returned if it never shows up.
+func (a *Azure) getSecretBundle(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, secretName string) (keyvault.SecretBundle, error) {
+	for attempt := 0; ; attempt++ {
+		// waiting for an expected version must not be answered from the cache
+		secretResp, err := a.fetchSecretBundle(ctx, secretName, ref.Version, ref.ExpectedVersion == "")
+		if err != nil {
+			return keyvault.SecretBundle{}, err
+		}
+		if ref.Version != "" || ref.ExpectedVersion == "" || secretResp.ID == nil ||
+			path.Base(*secretResp.ID) == ref.ExpectedVersion || attempt >= propagationRetries {
+			return secretResp, nil
+		}
+		log.V(1).Info("expected version not yet returned, retrying", "key", ref.Key, "version", path.Base(*secretResp.ID), "expected", ref.ExpectedVersion)
+		select {
+		case <-ctx.Done():
+			return keyvault.SecretBundle{}, ctx.Err()
+		case <-time.After(propagationRetryInterval):
+		}
+	}
+}
+
+// Fetches the secret bundle, from the value cache if enabled and useCache is set.
+func (a *Azure) fetchSecretBundle(ctx context.Context, secretName, version string, useCache bool) (keyvault.SecretBundle, error) {
+	key := valueCacheKey(*a.provider.VaultURL, defaultObjType, secretName, version)
+	if useCache {
+		if bundle, ok := a.values.get(key, a.now()); ok {
+			return bundle, nil
+		}
+	}
+	secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	err = parseError(err)
+	if err != nil {
+		return keyvault.SecretBundle{}, err
+	}
+	a.values.add(key, secretResp, a.now())
+	return secretResp, nil
+}
+
+// nextSecretPage advances the iterator, retrying transient errors with an exponential backoff
+// so a throttled page does not discard the secrets collected so far.
+func (a *Azure) nextSecretPage(ctx context.Context, iter *keyvault.SecretListResultIterator) error {
+	return a.nextListPage(ctx, defaultObjType, iter.NextWithContext)
+}
+
+// Calls next until it succeeds, fails with a permanent error or the list retries are exhausted.
+func (a *Azure) nextListPage(ctx context.Context, objectType string, next func(context.Context) error) error {
+	retries := listRetries(a.provider)
+	for attempt := 0; ; attempt++ {
+		err := next(ctx)
+		if err == nil || attempt >= retries || !isTransient(err) {
+			return err
+		}
+		log.V(1).Info("transient error listing objects, retrying", "type", objectType, "attempt", attempt+1, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryDelay(err, listRetryInterval, attempt)):
+		}
+	}
+}
+
+// Returns the retries of a page fetch, ListRetries if set and MaxRetries like for every other read otherwise.
+func listRetries(p *esv1beta1.AzureKVProvider) int {
+	switch {
+	case p.ListRetries != nil:
+		return int(*p.ListRetries)
+	case p.MaxRetries != nil:
+		return int(*p.MaxRetries)
+	default:
+		return defaultMaxRetries
+	}
+}
+
+// isTransient reports whether err is a throttling or server error worth retrying.
+func isTransient(err error) bool {
+	aerr := autorest.DetailedError{}
+	if !errors.As(err, &aerr) {
+		return false
+	}
+	code, ok := aerr.StatusCode.(int)
+	return ok && (code == http.StatusTooManyRequests || code >= http.StatusInternalServerError)
+}
+
+// getX509Certificate fetches a certificate and parses its DER encoded CER contents.
+func (a *Azure) getX509Certificate(ctx context.Context, certName, version string) (*x509.Certificate, error) {
+	certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, certName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if certResp.Cer == nil {
+		return nil, errors.New(errMissingCertificate)
+	}
+	cert, err := x509.ParseCertificate(*certResp.Cer)
+	if err != nil {
+		return nil, fmt.Errorf(errParseCertificate, err)
+	}
+	return cert, nil
+}
+
+// certificateStatus reports whether the certificate is valid, expired or not yet valid.
+func (a *Azure) certificateStatus(cert *x509.Certificate) []byte {
+	now := a.now()
+	switch {
+	case now.Before(cert.NotBefore):
+		return []byte(certStatusNotYetValid)
+	case now.After(cert.NotAfter):
+		return []byte(certStatusExpired)
+	default:
+		return []byte(certStatusValid)
+	}
+}
+
+func (a *Azure) now() time.Time {
+	if a.clock == nil {
+		return time.Now()
+	}
+	return a.clock.Now()
+}
+
+// checkSecretAge fails, or warns depending on MaxSecretAgePolicy, if the secret was last updated
+// longer than MaxSecretAge ago. Secrets without timestamps are not checked.
+func (a *Azure) checkSecretAge(secretName string, attrs *keyvault.SecretAttributes) error {
+	if a.provider.MaxSecretAge == nil || attrs == nil {
+		return nil
+	}
+	updated := attrs.Updated
+	if updated == nil {
+		updated = attrs.Created
+	}
+	if updated == nil {
+		return nil
+	}
+	age := a.now().Sub(time.Time(*updated))
+	if age <= a.provider.MaxSecretAge.Duration {
+		return nil
+	}
+	if a.provider.MaxSecretAgePolicy == esv1beta1.AzureKVMaxSecretAgeWarn {
+		log.Info("secret is older than the maximum age", "secret", secretName, "age", age.String(), "maxAge", a.provider.MaxSecretAge.Duration.String())
+		return nil
+	}
+	return fmt.Errorf(errSecretTooOld, ErrSecretTooOld, secretName, age.Round(time.Second))
+}
+
+// checkCertificateName probes for a certificate sharing the name of the secret, if the guard is enabled.
+// Failed probes, e.g. without permission to read certificates, are logged and do not fail the read.
+func (a *Azure) checkCertificateName(ctx context.Context, secretName string) error {
+	if a.provider.CertificateNameGuard == "" {
+		return nil
+	}
+	_, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, secretName, "")
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+	if err != nil {
+		if !errors.Is(parseError(err), esv1beta1.NoSecretErr) {
+			log.V(1).Info("could not check for a certificate with the same name", "secret", secretName, "error", err)
+		}
+		return nil
+	}
+	if a.provider.CertificateNameGuard == esv1beta1.AzureKVCertificateNameGuardWarn {
+		log.Info("a certificate with the same name exists, use the cert/ object type to read the certificate", "secret", secretName)
+		return nil
+	}
+	return fmt.Errorf(errCertificateNameGuard, secretName, secretName)
+}
+
+// isActive returns false if the secret's NotBefore date lies in the future.
+func (a *Azure) isActive(attrs *keyvault.SecretAttributes) bool {
+	if attrs == nil || attrs.NotBefore == nil {
+		return true
+	}
+	return !a.now().Before(time.Time(*attrs.NotBefore))
+}
+
+// isCurrent returns false if the secret's NotBefore date lies in the future
+// or its Expires date lies in the past. Missing dates are not checked.
+func (a *Azure) isCurrent(attrs *keyvault.SecretAttributes) bool {
+	if !a.isActive(attrs) {
+		return false
+	}
+	if attrs == nil || attrs.Expires == nil {
+		return true
+	}
+	return a.now().Before(time.Time(*attrs.Expires))
+}
+
+// warnIfStaleVersion logs a warning if a newer enabled version than the pinned one exists.
+// Errors are logged only, the pinned value is returned regardless.
+func (a *Azure) warnIfStaleVersion(ctx context.Context, secretName, version string, attrs *keyvault.SecretAttributes) {
+	newer, err := a.newerVersionExists(ctx, secretName, attrs)
+	if err != nil {
+		log.Error(err, "could not check for newer secret versions", "secret", secretName)
+		return
+	}
+	if newer {
+		log.Info("a newer version of the secret exists than the pinned one", "secret", secretName, "version", version)
+	}
+}
+
+func (a *Azure) newerVersionExists(ctx context.Context, secretName string, attrs *keyvault.SecretAttributes) (bool, error) {
+	if attrs == nil || attrs.Created == nil {
+		return false, nil
+	}
+	pinned := time.Time(*attrs.Created)
+	versions, err := a.baseClient.GetSecretVersionsComplete(ctx, *a.provider.VaultURL, secretName, nil)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecretVersions, err)
+	if err != nil {
+		return false, err
+	}
+	for versions.NotDone() {
+		item := versions.Value()
+		if item.Attributes != nil && item.Attributes.Created != nil &&
+			item.Attributes.Enabled != nil && *item.Attributes.Enabled &&
+			time.Time(*item.Attributes.Created).After(pinned) {
+			return true, nil
+		}
+		if err := versions.NextWithContext(ctx); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
 // returns a SecretBundle with the tags values.
 func (a *Azure) getSecretTags(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string]*string, error) {
-	_, secretName := getObjType(ref)
+	_, secretName := a.resolveObjType(ref)
 	secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, secretName, ref.Version)
 	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
 	err = parseError(err)
@@ -692,31 +2013,120 @@ func (a *Azure) getSecretTags(ctx context.Context, ref esv1beta1.ExternalSecretD
 // Implements store.Client.GetSecretMap Interface.
 // New version of GetSecretMap.
 func (a *Azure) GetSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
-	objectType, secretName := getObjType(ref)
+	az, ref, err := a.forRefVault(ref)
+	if err != nil {
+		return nil, err
+	}
+	if az != a {
+		return az.GetSecretMap(ctx, ref)
+	}
+	ref, err = normalizeVersion(ref)
+	if err != nil {
+		return nil, err
+	}
+	objectType, secretName := a.resolveObjType(ref)
 
 	switch objectType {
 	case defaultObjType:
-		data, err := a.GetSecret(ctx, ref)
+		secretMap, secretResp, err := a.readSecretMap(ctx, ref, secretName)
 		if err != nil {
 			return nil, err
 		}
-
-		if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
-			tags, _ := a.getSecretTags(ctx, ref)
-			return getSecretMapProperties(tags, ref.Key, ref.Property), nil
+		// there is no metadata to add if the default value was returned
+		if ref.IncludeMetadata && secretResp != nil {
+			if err := addSecretMetadata(*secretResp, secretMap); err != nil {
+				return nil, err
+			}
 		}
+		secretMap, err = transformKeys(secretMap, ref.KeyTransform)
+		if err != nil {
+			return nil, err
+		}
+		return a.sanitizeKeys(secretMap)
 
-		return getSecretMapMap(data)
-
+	case objectTypeCertNginx:
+		// returns the certificate chain and private key as fullchain.pem and privkey.pem
+		return a.getCertificateNginxBundle(ctx, secretName, ref.Version)
 	case objectTypeCert:
-		return nil, fmt.Errorf(errDataFromCert)
+		if !a.provider.DataFromCertificates {
+			return nil, fmt.Errorf(errDataFromCert)
+		}
+		// returns the certificate chain and private key as tls.crt and tls.key
+		return a.getCertificateTLSMap(ctx, secretName, ref.Version)
 	case objectTypeKey:
-		return nil, fmt.Errorf(errDataFromKey)
+		if !a.provider.DataFromKeys {
+			return nil, fmt.Errorf(errDataFromKey)
+		}
+		// returns the components of the JWK, like kty, n and e for RSA keys
+		return a.getKeyMap(ctx, ref)
 	}
 	return nil, fmt.Errorf(errUnknownObjectType, secretName)
 }
 
-func getSecretMapMap(data []byte) (map[string][]byte, error) {
+// Reads the properties of a JSON secret, or its tags with MetadataPolicy Fetch or FromTags,
+// before metadata and key transforms are applied. The secret is fetched once, the fetched
+// bundle is returned for its metadata. It is nil if the secret does not exist and the default value was used.
+func (a *Azure) readSecretMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, secretName string) (map[string][]byte, *keyvault.SecretBundle, error) {
+	if ref.FromTags {
+		secretResp, err := a.fetchSecretBundle(ctx, secretName, ref.Version, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		return secretTagsMap(secretResp), &secretResp, nil
+	}
+	var secretResp *keyvault.SecretBundle
+	data, err := a.getLoggedSecret(ctx, ref, func(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) ([]byte, error) {
+		if err := a.checkSecretName(secretName); err != nil {
+			return nil, err
+		}
+		bundle, err := a.readSecretBundle(ctx, ref, secretName)
+		if err != nil {
+			return nil, err
+		}
+		secretResp = &bundle
+		return a.secretBundleValue(ctx, ref, secretName, bundle)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if ref.MetadataPolicy == esv1beta1.ExternalSecretMetadataPolicyFetch {
+		tags, _ := a.getSecretTags(ctx, ref)
+		return getSecretMapProperties(tags, ref.Key, ref.Property), secretResp, nil
+	}
+	secretMap, err := decodeSecretMap(ref, data)
+	if err != nil && !ref.IncludeMetadata {
+		return nil, nil, err
+	}
+	if err != nil {
+		log.V(1).Info("secret value is not a JSON object, returning its metadata only", "key", ref.Key)
+		secretMap = make(map[string][]byte)
+	}
+	return secretMap, secretResp, nil
+}
+
+// Returns the tags of a secret keyed by tag name, tags without a value are skipped.
+func secretTagsMap(secretResp keyvault.SecretBundle) map[string][]byte {
+	tagsMap := make(map[string][]byte, len(secretResp.Tags))
+	for k, v := range convertTags(secretResp.Tags) {
+		tagsMap[k] = []byte(v)
+	}
+	return tagsMap
+}
+
+func (a *Azure) getKeyMap(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (map[string][]byte, error) {
+	data, err := a.getSecretValue(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return getSecretMapMap(ref.Key, data)
+}
+
+func getSecretMapMap(key string, data []byte) (map[string][]byte, error) {
+	if json.Valid(data) {
+		if kind := jsonKind(data); kind != "object" {
+			return nil, fmt.Errorf(errDataFromJSONType, key, kind)
+		}
+	}
 	kv := make(map[string]json.RawMessage)
 	err := json.Unmarshal(data, &kv)
 	if err != nil {
@@ -736,6 +2146,74 @@ func getSecretMapMap(data []byte) (map[string][]byte, error) {
 	return secretData, nil
 }
 
+// Returns the kind of the top-level value of a valid JSON document, e.g. object, array or string.
+func jsonKind(data []byte) string {
+	res := gjson.ParseBytes(data)
+	switch {
+	case res.IsObject():
+		return "object"
+	case res.IsArray():
+		return "array"
+	case res.Type == gjson.String:
+		return "string"
+	case res.Type == gjson.Number:
+		return "number"
+	case res.Type == gjson.True, res.Type == gjson.False:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+// Applies the key transform to every key of the secret map,
+// failing if two keys end up with the same transformed key.
+func transformKeys(secretMap map[string][]byte, transform esv1beta1.ExternalSecretKeyTransform) (map[string][]byte, error) {
+	var fn func(string) string
+	switch transform {
+	case esv1beta1.ExternalSecretKeyTransformNone, "":
+		return secretMap, nil
+	case esv1beta1.ExternalSecretKeyTransformUpper:
+		fn = strings.ToUpper
+	case esv1beta1.ExternalSecretKeyTransformLower:
+		fn = strings.ToLower
+	default:
+		return nil, fmt.Errorf(errUnknownKeyTransform, transform)
+	}
+	return remapKeys(secretMap, fn, errKeyTransformCollision)
+}
+
+// Replaces the characters not allowed in Kubernetes secret keys when KeySanitize is set,
+// failing if two keys end up with the same sanitized key.
+func (a *Azure) sanitizeKeys(secretMap map[string][]byte) (map[string][]byte, error) {
+	if a.provider.KeySanitize == nil {
+		return secretMap, nil
+	}
+	replacement := a.provider.KeySanitize.Replacement
+	return remapKeys(secretMap, func(k string) string {
+		return invalidKeyChars.ReplaceAllLiteralString(k, replacement)
+	}, errKeySanitizeCollision)
+}
+
+// Applies fn to every key of the secret map.
+// errCollision is formatted with both colliding keys in order and the key they map to.
+func remapKeys(secretMap map[string][]byte, fn func(string) string, errCollision string) (map[string][]byte, error) {
+	remapped := make(map[string][]byte, len(secretMap))
+	origins := make(map[string]string, len(secretMap))
+	for k, v := range secretMap {
+		newKey := fn(k)
+		if origin, ok := origins[newKey]; ok {
+			first, second := origin, k
+			if second < first {
+				first, second = second, first
+			}
+			return nil, fmt.Errorf(errCollision, first, second, newKey)
+		}
+		origins[newKey] = k
+		remapped[newKey] = v
+	}
+	return remapped, nil
+}
+
 func getSecretMapProperties(tags map[string]*string, key, property string) map[string][]byte {
 	tagByteArray := make(map[string][]byte)
 	if property != "" {
@@ -753,7 +2231,14 @@ func getSecretMapProperties(tags map[string]*string, key, property string) map[s
 	return tagByteArray
 }
 
+// Acquires the workload identity token within AuthorizerTimeout.
 func (a *Azure) authorizerForWorkloadIdentity(ctx context.Context, tokenProvider tokenProviderFunc) (autorest.Authorizer, error) {
+	return a.withAuthorizerTimeout(ctx, func(ctx context.Context) (autorest.Authorizer, error) {
+		return a.workloadIdentityAuthorizer(ctx, tokenProvider)
+	})
+}
+
+func (a *Azure) workloadIdentityAuthorizer(ctx context.Context, tokenProvider tokenProviderFunc) (autorest.Authorizer, error) {
 	aadEndpoint := AadEndpointForType(a.provider.EnvironmentType)
 	kvResource := kvResourceForProviderConfig(a.provider.EnvironmentType)
 	// if no serviceAccountRef was provided
@@ -825,17 +2310,34 @@ func FetchSAToken(ctx context.Context, ns, name string, audiences []string, kube
 
 // tokenProvider satisfies the adal.OAuthTokenProvider interface.
 type tokenProvider struct {
+	mu          sync.RWMutex
 	accessToken string
+	acquire     func(ctx context.Context, opts ...confidential.AcquireByCredentialOption) (confidential.AuthResult, error)
 }
 
 type tokenProviderFunc func(ctx context.Context, token, clientID, tenantID, aadEndpoint, kvResource string) (adal.OAuthTokenProvider, error)
 
 func NewTokenProvider(ctx context.Context, token, clientID, tenantID, aadEndpoint, kvResource string) (adal.OAuthTokenProvider, error) {
+	return newTokenProvider(ctx, nil, token, clientID, tenantID, aadEndpoint, kvResource)
+}
+
+// Returns a tokenProviderFunc sending the token exchange through httpClient, the default client if nil.
+func tokenProviderWithClient(httpClient *http.Client) tokenProviderFunc {
+	return func(ctx context.Context, token, clientID, tenantID, aadEndpoint, kvResource string) (adal.OAuthTokenProvider, error) {
+		return newTokenProvider(ctx, httpClient, token, clientID, tenantID, aadEndpoint, kvResource)
+	}
+}
+
+func newTokenProvider(ctx context.Context, httpClient *http.Client, token, clientID, tenantID, aadEndpoint, kvResource string) (adal.OAuthTokenProvider, error) {
 	// exchange token with Azure AccessToken
 	cred := confidential.NewCredFromAssertionCallback(func(ctx context.Context, aro confidential.AssertionRequestOptions) (string, error) {
 		return token, nil
 	})
-	cClient, err := confidential.New(fmt.Sprintf("%s%s/oauth2/token", aadEndpoint, tenantID), clientID, cred)
+	var opts []confidential.Option
+	if httpClient != nil {
+		opts = append(opts, confidential.WithHTTPClient(httpClient))
+	}
+	cClient, err := confidential.New(fmt.Sprintf("%s%s/oauth2/token", aadEndpoint, tenantID), clientID, cred, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -844,28 +2346,190 @@ func NewTokenProvider(ctx context.Context, token, clientID, tenantID, aadEndpoin
 	if !strings.Contains(kvResource, ".default") {
 		scope = fmt.Sprintf("%s/.default", kvResource)
 	}
-	authRes, err := cClient.AcquireTokenByCredential(ctx, []string{
-		scope,
-	})
+	tp := &tokenProvider{
+		acquire: func(ctx context.Context, opts ...confidential.AcquireByCredentialOption) (confidential.AuthResult, error) {
+			return cClient.AcquireTokenByCredential(ctx, []string{
+				scope,
+			}, opts...)
+		},
+	}
+	authRes, err := tp.acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &tokenProvider{
-		accessToken: authRes.AccessToken,
-	}, nil
+	tp.accessToken = authRes.AccessToken
+	return tp, nil
 }
 
 func (t *tokenProvider) OAuthToken() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	return t.accessToken
 }
 
-func (a *Azure) authorizerForManagedIdentity() (autorest.Authorizer, error) {
-	msiConfig := kvauth.NewMSIConfig()
-	msiConfig.Resource = kvResourceForProviderConfig(a.provider.EnvironmentType)
-	if a.provider.IdentityID != nil {
-		msiConfig.ClientID = *a.provider.IdentityID
+// RefreshWithClaims exchanges the token again, requesting the claims of a CAE challenge.
+func (t *tokenProvider) RefreshWithClaims(ctx context.Context, claims string) error {
+	if t.acquire == nil {
+		return errors.New(errTokenNotRefreshable)
+	}
+	authRes, err := t.acquire(ctx, confidential.WithClaims(claims))
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.accessToken = authRes.AccessToken
+	return nil
+}
+
+// Returns the HTTP client sending the requests to the vault, with the provider's ClientTimeout.
+func newHTTPClient(provider *esv1beta1.AzureKVProvider) *http.Client {
+	timeout := defaultClientTimeout
+	if provider.ClientTimeout != nil && provider.ClientTimeout.Duration > 0 {
+		timeout = provider.ClientTimeout.Duration
+	}
+	return &http.Client{Transport: newTransport(provider), Timeout: timeout}
+}
+
+// Returns the transport used to connect to the vault, tuned by the provider's connection pooling options.
+func newTransport(provider *esv1beta1.AzureKVProvider) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	transport.IdleConnTimeout = defaultIdleConnTimeout
+	if provider.MaxIdleConnsPerHost != nil {
+		transport.MaxIdleConnsPerHost = int(*provider.MaxIdleConnsPerHost)
+	}
+	if provider.IdleConnTimeout != nil {
+		transport.IdleConnTimeout = provider.IdleConnTimeout.Duration
+	}
+	return transport
+}
+
+// Warns about auth settings which are ignored by the configured auth type,
+// or fails if StrictAuthConfig is set.
+func (a *Azure) checkAuthConfig() error {
+	authType := esv1beta1.AzureServicePrincipal
+	if a.provider.AuthType != nil {
+		authType = *a.provider.AuthType
+	}
+	if isSet(a.provider.IdentityID) && isSet(a.provider.IdentityResourceID) {
+		return errors.New(errIdentitySelectors)
+	}
+	if (!isSet(a.provider.IdentityID) && !isSet(a.provider.IdentityResourceID)) || authType == esv1beta1.AzureManagedIdentity {
+		return nil
+	}
+	field := "identityId"
+	if !isSet(a.provider.IdentityID) {
+		field = "identityResourceId"
+	}
+	err := fmt.Errorf(errIdentityIDIgnored, field, authType)
+	if a.provider.StrictAuthConfig {
+		return err
+	}
+	log.Info(err.Error())
+	return nil
+}
+
+// Acquires the managed identity authorizer, retrying up to MSIRetries times so a transient
+// IMDS failure on a node under pressure does not fail the store. Every attempt is bounded by AuthorizerTimeout.
+func (a *Azure) authorizerForManagedIdentity(ctx context.Context) (autorest.Authorizer, error) {
+	retries := defaultMSIRetries
+	if a.provider.MSIRetries != nil {
+		retries = int(*a.provider.MSIRetries)
+	}
+	interval := defaultMSIRetryInterval
+	if a.provider.MSIRetryInterval != nil && a.provider.MSIRetryInterval.Duration > 0 {
+		interval = a.provider.MSIRetryInterval.Duration
+	}
+	newAuthorizer := func(ctx context.Context) (autorest.Authorizer, error) {
+		return a.authorizerFactory().managedIdentity(ctx, a)
+	}
+	for attempt := 0; ; attempt++ {
+		authorizer, err := a.withAuthorizerTimeout(ctx, newAuthorizer)
+		if err == nil || attempt >= retries {
+			return authorizer, err
+		}
+		log.Info("could not acquire the managed identity authorizer, retrying", "attempt", attempt+1, "interval", interval.String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Acquires the first token from IMDS, so an unavailable endpoint fails the attempt instead of the first vault request.
+func (a *Azure) managedIdentityAuthorizer(ctx context.Context) (autorest.Authorizer, error) {
+	spToken, err := a.managedIdentityToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := spToken.EnsureFreshWithContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get oauth token from MSI: %w", err)
+	}
+	return autorest.NewBearerAuthorizer(spToken), nil
+}
+
+// Selects the user-assigned identity by client ID or resource ID, the system-assigned identity is used if neither is set.
+// Object IDs are not supported, the MSI client of the SDK can not send them.
+func (a *Azure) managedIdentityOptions() *adal.ManagedIdentityOptions {
+	opts := &adal.ManagedIdentityOptions{}
+	if isSet(a.provider.IdentityID) {
+		opts.ClientID = *a.provider.IdentityID
+	} else if isSet(a.provider.IdentityResourceID) {
+		opts.IdentityResourceID = *a.provider.IdentityResourceID
 	}
-	return msiConfig.Authorizer()
+	return opts
+}
+
+// Reports whether an optional string field is set to a non-empty value.
+func isSet(s *string) bool {
+	return s != nil && *s != ""
+}
+
+// withAuthorizerTimeout bounds newAuthorizer by AuthorizerTimeout, so an unresponsive AAD endpoint
+// does not block the store initialization. newAuthorizer must acquire its first token with ctx,
+// the request is cancelled when the deadline expires.
+func (a *Azure) withAuthorizerTimeout(ctx context.Context, newAuthorizer func(ctx context.Context) (autorest.Authorizer, error)) (autorest.Authorizer, error) {
+	timeout := defaultAuthorizerTimeout
+	if a.provider.AuthorizerTimeout != nil {
+		timeout = a.provider.AuthorizerTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	authorizer, err := newAuthorizer(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf(errAuthorizerTimeout, timeout, err)
+	}
+	return authorizer, err
+}
+
+// managedIdentityToken returns a token for the configured managed identity.
+// If MSIEndpoint is set it is used instead of the auto-detected endpoint.
+func (a *Azure) managedIdentityToken() (*adal.ServicePrincipalToken, error) {
+	resource := kvResourceForProviderConfig(a.provider.EnvironmentType)
+	opts := a.managedIdentityOptions()
+	var (
+		spToken *adal.ServicePrincipalToken
+		err     error
+	)
+	switch {
+	case a.provider.MSIEndpoint == nil:
+		spToken, err = adal.NewServicePrincipalTokenFromManagedIdentity(resource, opts)
+	// the non-deprecated constructor does not allow overriding the endpoint.
+	case opts.ClientID != "":
+		spToken, err = adal.NewServicePrincipalTokenFromMSIWithUserAssignedID(*a.provider.MSIEndpoint, resource, opts.ClientID) //nolint:staticcheck
+	case opts.IdentityResourceID != "":
+		spToken, err = adal.NewServicePrincipalTokenFromMSIWithIdentityResourceID(*a.provider.MSIEndpoint, resource, opts.IdentityResourceID) //nolint:staticcheck
+	default:
+		spToken, err = adal.NewServicePrincipalTokenFromMSI(*a.provider.MSIEndpoint, resource) //nolint:staticcheck
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oauth token from MSI: %w", err)
+	}
+	a.setTokenSender(spToken)
+	return spToken, nil
 }
 
 func (a *Azure) authorizerForServicePrincipal(ctx context.Context) (autorest.Authorizer, error) {
@@ -875,9 +2539,13 @@ func (a *Azure) authorizerForServicePrincipal(ctx context.Context) (autorest.Aut
 	if a.provider.AuthSecretRef == nil {
 		return nil, fmt.Errorf(errMissingSecretRef)
 	}
-	if a.provider.AuthSecretRef.ClientID == nil || a.provider.AuthSecretRef.ClientSecret == nil {
+	auth := a.provider.AuthSecretRef
+	if auth.ClientID == nil || (auth.ClientSecret == nil && auth.ClientCertificate == nil) {
 		return nil, fmt.Errorf(errMissingClientIDSecret)
 	}
+	if auth.ClientSecret != nil && auth.ClientCertificate != nil {
+		return nil, errors.New(errClientSecretAndCertificate)
+	}
 	clusterScoped := false
 	if a.store.GetKind() == esv1beta1.ClusterSecretStoreKind {
 		clusterScoped = true
@@ -886,6 +2554,9 @@ func (a *Azure) authorizerForServicePrincipal(ctx context.Context) (autorest.Aut
 	if err != nil {
 		return nil, err
 	}
+	if auth.ClientCertificate != nil {
+		return a.authorizerForClientCertificate(ctx, cid, clusterScoped)
+	}
 	csec, err := a.secretKeyRef(ctx, a.namespace, *a.provider.AuthSecretRef.ClientSecret, clusterScoped)
 	if err != nil {
 		return nil, err
@@ -893,11 +2564,69 @@ func (a *Azure) authorizerForServicePrincipal(ctx context.Context) (autorest.Aut
 	clientCredentialsConfig := kvauth.NewClientCredentialsConfig(cid, csec, *a.provider.TenantID)
 	clientCredentialsConfig.Resource = kvResourceForProviderConfig(a.provider.EnvironmentType)
 	clientCredentialsConfig.AADEndpoint = AadEndpointForType(a.provider.EnvironmentType)
-	return clientCredentialsConfig.Authorizer()
+	return a.withAuthorizerTimeout(ctx, func(ctx context.Context) (autorest.Authorizer, error) {
+		spToken, err := clientCredentialsConfig.ServicePrincipalToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SPT from client credentials: %w", err)
+		}
+		// the tenant is resolved by AAD when using a multi-tenant authority,
+		// make sure every issued token is bound to a concrete tenant.
+		if isMultiTenant(*a.provider.TenantID) {
+			spToken.SetRefreshCallbacks([]adal.TokenRefreshCallback{validateTenantClaim})
+		}
+		a.setTokenSender(spToken)
+		if err := spToken.EnsureFreshWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get token from client credentials: %w", err)
+		}
+		return autorest.NewBearerAuthorizer(spToken), nil
+	})
+}
+
+// Sends the token requests of spToken through the HTTP client of the vault requests, if set.
+func (a *Azure) setTokenSender(spToken *adal.ServicePrincipalToken) {
+	if a.httpClient != nil {
+		spToken.SetSender(a.httpClient)
+	}
+}
+
+// isMultiTenant returns true if the tenant is one of the AAD multi-tenant authorities.
+func isMultiTenant(tenantID string) bool {
+	return strings.EqualFold(tenantID, tenantCommon) || strings.EqualFold(tenantID, tenantOrganizations)
+}
+
+// validateTenantClaim ensures the access token carries a concrete tenant id claim.
+func validateTenantClaim(token adal.Token) error {
+	parts := strings.Split(token.AccessToken, ".")
+	if len(parts) != 3 {
+		return errors.New(errInvalidAccessToken)
+	}
+	payload, err := b64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf(errDecodeAccessToken, err)
+	}
+	var claims struct {
+		TenantID string `json:"tid"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return fmt.Errorf(errDecodeAccessToken, err)
+	}
+	if claims.TenantID == "" || isMultiTenant(claims.TenantID) {
+		return errors.New(errMissingTenantClaim)
+	}
+	return nil
 }
 
 // secretKeyRef fetch a secret key.
 func (a *Azure) secretKeyRef(ctx context.Context, namespace string, secretRef smmeta.SecretKeySelector, clusterScoped bool) (string, error) {
+	keyBytes, err := a.secretKeyRefBytes(ctx, namespace, secretRef, clusterScoped)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(keyBytes)), nil
+}
+
+// Returns the raw bytes of the secret key, for binary data like a PKCS#12 client certificate.
+func (a *Azure) secretKeyRefBytes(ctx context.Context, namespace string, secretRef smmeta.SecretKeySelector, clusterScoped bool) ([]byte, error) {
 	var secret corev1.Secret
 	ref := types.NamespacedName{
 		Name:      secretRef.Name,
@@ -906,41 +2635,88 @@ func (a *Azure) secretKeyRef(ctx context.Context, namespace string, secretRef sm
 	if clusterScoped && secretRef.Namespace != nil {
 		ref.Namespace = *secretRef.Namespace
 	}
+	// a cluster scoped store has no namespace of its own, only referent
+	// authentication provides one through the ExternalSecret.
+	if clusterScoped && ref.Namespace == "" {
+		return nil, errors.New(errClusterRefNamespace)
+	}
 	err := a.crClient.Get(ctx, ref, &secret)
 	if err != nil {
-		return "", fmt.Errorf(errFindSecret, ref.Namespace, ref.Name, err)
+		return nil, fmt.Errorf(errFindSecret, ref.Namespace, ref.Name, err)
 	}
 	keyBytes, ok := secret.Data[secretRef.Key]
 	if !ok {
-		return "", fmt.Errorf(errFindDataKey, secretRef.Key, secretRef.Name, namespace)
+		return nil, fmt.Errorf(errFindDataKey, secretRef.Key, secretRef.Name, ref.Namespace)
 	}
-	value := strings.TrimSpace(string(keyBytes))
-	return value, nil
-}
-
-func (a *Azure) Close(_ context.Context) error {
-	return nil
+	return keyBytes, nil
 }
 
+// Validate reports the store as ready, its configuration and credentials were checked by NewClient.
+// With ValidateVaultAccess the vault is listed as well: an unreachable vault is reported as unknown,
+// rejected credentials and any other error of the listing fail the store.
 func (a *Azure) Validate() (esv1beta1.ValidationResult, error) {
 	if a.store.GetKind() == esv1beta1.ClusterSecretStoreKind && isReferentSpec(a.provider) {
 		return esv1beta1.ValidationResultUnknown, nil
 	}
-	return esv1beta1.ValidationResultReady, nil
+	// the configuration was checked by NewClient, listing the vault on every reconcile is opt-in
+	if !a.provider.ValidateVaultAccess {
+		return esv1beta1.ValidationResultReady, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), validateTimeout)
+	defer cancel()
+	_, err := a.baseClient.GetSecretsComplete(ctx, *a.provider.VaultURL, pointer.To(int32(1)))
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecrets, err)
+	if err == nil {
+		return esv1beta1.ValidationResultReady, nil
+	}
+	err = classifyVaultError(err)
+	var unreachable VaultUnreachableError
+	if errors.As(err, &unreachable) {
+		return esv1beta1.ValidationResultUnknown, err
+	}
+	return esv1beta1.ValidationResultError, err
+}
+
+// Wraps errors caused by rejected credentials or an unreachable vault into their typed errors.
+func classifyVaultError(err error) error {
+	aerr := autorest.DetailedError{}
+	if errors.As(err, &aerr) && (aerr.StatusCode == http.StatusUnauthorized || aerr.StatusCode == http.StatusForbidden) {
+		return VaultUnauthorizedError{Err: err}
+	}
+	var refreshErr adal.TokenRefreshError
+	if errors.As(err, &refreshErr) {
+		return VaultUnauthorizedError{Err: err}
+	}
+	// the token endpoint answers rejected client credentials or assertions with a 400 or 401
+	var callErr msalerrors.CallErr
+	if errors.As(err, &callErr) && callErr.Resp != nil &&
+		(callErr.Resp.StatusCode == http.StatusBadRequest || callErr.Resp.StatusCode == http.StatusUnauthorized) {
+		return VaultUnauthorizedError{Err: err}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return VaultUnreachableError{Err: err}
+	}
+	return err
 }
 
 func isReferentSpec(prov *esv1beta1.AzureKVProvider) bool {
-	if prov.AuthSecretRef != nil &&
-		((prov.AuthSecretRef.ClientID != nil &&
-			prov.AuthSecretRef.ClientID.Namespace == nil) ||
-			(prov.AuthSecretRef.ClientSecret != nil &&
-				prov.AuthSecretRef.ClientSecret.Namespace == nil)) {
-		return true
+	if prov.AuthSecretRef != nil {
+		auth := prov.AuthSecretRef
+		for _, ref := range []*smmeta.SecretKeySelector{auth.ClientID, auth.ClientSecret, auth.ClientCertificate, auth.ClientCertificatePassword} {
+			if ref != nil && ref.Namespace == nil {
+				return true
+			}
+		}
 	}
 	if prov.ServiceAccountRef != nil &&
 		prov.ServiceAccountRef.Namespace == nil {
 		return true
 	}
+	if prov.Keystore != nil &&
+		prov.Keystore.PasswordSecretRef.Namespace == nil {
+		return true
+	}
 	return false
 }
 
@@ -976,6 +2752,96 @@ func kvResourceForProviderConfig(t esv1beta1.AzureEnvironmentType) string {
 	return strings.TrimSuffix(res, "/")
 }
 
+// Key Vault DNS suffixes of the Azure clouds, used to detect a vault URL of a different cloud,
+// and the private DNS zones of their private endpoints.
+var vaultDNSSuffixes = []struct {
+	environment       esv1beta1.AzureEnvironmentType
+	suffix            string
+	privateLinkSuffix string
+}{
+	{esv1beta1.AzureEnvironmentPublicCloud, azure.PublicCloud.KeyVaultDNSSuffix, "privatelink.vaultcore.azure.net"},
+	{esv1beta1.AzureEnvironmentUSGovernmentCloud, azure.USGovernmentCloud.KeyVaultDNSSuffix, "privatelink.vaultcore.usgovcloudapi.net"},
+	{esv1beta1.AzureEnvironmentChinaCloud, azure.ChinaCloud.KeyVaultDNSSuffix, "privatelink.vaultcore.azure.cn"},
+	{esv1beta1.AzureEnvironmentGermanCloud, azure.GermanCloud.KeyVaultDNSSuffix, "privatelink.vaultcore.microsoftazure.de"},
+}
+
+// checkVaultURL returns an error if the vault URL is not a https URL without a path,
+// which the SDK would only report as a confusing URL error on the first request.
+// http is only accepted for loopback hosts, like emulators, so bearer tokens are never sent in cleartext
+// over the network. Hosts of a known cloud are checked against environmentType by checkVaultEnvironment.
+func (a *Azure) checkVaultURL() error {
+	if a.provider.VaultURL == nil {
+		return nil
+	}
+	var reason string
+	u, err := url.Parse(*a.provider.VaultURL)
+	switch {
+	case err != nil:
+		reason = "it can not be parsed"
+	case u.Scheme != "https" && u.Scheme != "http":
+		reason = "the scheme must be https"
+	case u.Scheme == "http" && !isLoopbackHost(u.Hostname()):
+		reason = "http is only accepted for loopback hosts"
+	case u.Host == "":
+		reason = "it has no host"
+	case u.Path != "" && u.Path != "/":
+		reason = "it must not have a path"
+	case u.User != nil || u.RawQuery != "" || u.Fragment != "":
+		reason = "it must not have user info, a query or a fragment"
+	default:
+		return nil
+	}
+	return fmt.Errorf(errInvalidVaultURL, *a.provider.VaultURL, reason, a.vaultDNSSuffix())
+}
+
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Returns the Key Vault DNS suffix of the configured cloud.
+func (a *Azure) vaultDNSSuffix() string {
+	for _, cloud := range vaultDNSSuffixes {
+		if cloud.environment == a.provider.EnvironmentType {
+			return cloud.suffix
+		}
+	}
+	return azure.PublicCloud.KeyVaultDNSSuffix
+}
+
+// checkVaultEnvironment returns an error if the vault URL belongs to a different cloud than EnvironmentType,
+// as the token would be issued for the wrong audience. Hosts of no known cloud, like private endpoints, are accepted.
+func (a *Azure) checkVaultEnvironment() error {
+	if a.provider.VaultURL == nil {
+		return nil
+	}
+	configured := a.provider.EnvironmentType
+	if configured == "" {
+		configured = esv1beta1.AzureEnvironmentPublicCloud
+	}
+	host := strings.ToLower(a.vaultHost())
+	for _, cloud := range vaultDNSSuffixes {
+		known := strings.HasSuffix(host, "."+cloud.suffix) || strings.HasSuffix(host, "."+cloud.privateLinkSuffix)
+		if known && cloud.environment != configured {
+			return fmt.Errorf(errVaultEnvironmentMismatch, *a.provider.VaultURL, cloud.environment, configured)
+		}
+	}
+	return nil
+}
+
+// Splits the object type prefix from the secret name like getObjType,
+// resolving the object type aliases configured in the store.
+func (a *Azure) resolveObjType(ref esv1beta1.ExternalSecretDataRemoteRef) (string, string) {
+	objectType, secretName := getObjType(ref)
+	if alias, ok := a.provider.ObjectTypeAliases[objectType]; ok {
+		objectType = alias
+	}
+	return objectType, secretName
+}
+
 func getObjType(ref esv1beta1.ExternalSecretDataRemoteRef) (string, string) {
 	objectType := defaultObjType
 
@@ -985,13 +2851,14 @@ func getObjType(ref esv1beta1.ExternalSecretDataRemoteRef) (string, string) {
 	if len(nameSplitted) > 1 {
 		objectType = nameSplitted[0]
 		secretName = nameSplitted[1]
-		// TODO: later tokens can be used to read the secret tags
+		// a third token is the version, see normalizeVersion
 	}
 	return objectType, secretName
 }
 
-func isValidSecret(checkTags, checkName bool, ref esv1beta1.ExternalSecretFind, secret keyvault.SecretItem) (bool, string) {
-	if secret.ID == nil || !*secret.Attributes.Enabled {
+func isValidSecret(checkTags, checkName bool, ref esv1beta1.ExternalSecretFind, nameRe *regexp.Regexp, secret keyvault.SecretItem) (bool, string) {
+	// secrets without attributes or an Enabled flag are treated as disabled
+	if secret.ID == nil || secret.Attributes == nil || secret.Attributes.Enabled == nil || !*secret.Attributes.Enabled {
 		return false, ""
 	}
 
@@ -1000,25 +2867,106 @@ func isValidSecret(checkTags, checkName bool, ref esv1beta1.ExternalSecretFind,
 	}
 
 	secretName := path.Base(*secret.ID)
-	if checkName && !okByName(ref, secretName) {
+	if checkName && !okByName(ref, nameRe, secretName) {
+		return false, ""
+	}
+	if !okByPath(ref, secretName) {
 		return false, ""
 	}
 
 	return true, secretName
 }
 
-func okByName(ref esv1beta1.ExternalSecretFind, secretName string) bool {
-	matches, _ := regexp.MatchString(ref.Name.RegExp, secretName)
-	return matches
+// Reports whether ref filters secrets by name.
+func hasNameFilter(ref esv1beta1.ExternalSecretFind) bool {
+	return ref.Name != nil && (ref.Name.RegExp != "" || ref.Name.Prefix != "" || ref.Name.Suffix != "")
+}
+
+// Key Vault has no folders and can not list by prefix, so names are matched against
+// the find path after listing. Names equal to the path are skipped, their key would be empty.
+func okByPath(ref esv1beta1.ExternalSecretFind, name string) bool {
+	if ref.Path == nil || *ref.Path == "" {
+		return true
+	}
+	return strings.HasPrefix(name, *ref.Path) && len(name) > len(*ref.Path)
+}
+
+// Removes the find path from the keys of values, so e.g. app-prod-db-password is returned as db-password
+// for the path app-prod-.
+func stripFindPath(ref esv1beta1.ExternalSecretFind, values map[string][]byte) map[string][]byte {
+	if ref.Path == nil || *ref.Path == "" {
+		return values
+	}
+	stripped := make(map[string][]byte, len(values))
+	for k, v := range values {
+		stripped[strings.TrimPrefix(k, *ref.Path)] = v
+	}
+	return stripped
+}
+
+// Compiles the name regular expression of ref once for all listed names, it is nil if ref has none.
+func compileFindRegExp(ref esv1beta1.ExternalSecretFind) (*regexp.Regexp, error) {
+	if ref.Name == nil || ref.Name.RegExp == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(ref.Name.RegExp)
+	if err != nil {
+		return nil, fmt.Errorf(errInvalidFindRegExp, ref.Name.RegExp, err)
+	}
+	return re, nil
+}
+
+// Matches the prefix and suffix before the regular expression, as they are cheaper to check.
+// nameRe is the compiled name regular expression of ref, see compileFindRegExp.
+func okByName(ref esv1beta1.ExternalSecretFind, nameRe *regexp.Regexp, secretName string) bool {
+	if !strings.HasPrefix(secretName, ref.Name.Prefix) || !strings.HasSuffix(secretName, ref.Name.Suffix) {
+		return false
+	}
+	return nameRe == nil || nameRe.MatchString(secretName)
 }
 
 func okByTags(ref esv1beta1.ExternalSecretFind, secret keyvault.SecretItem) bool {
-	tagsFound := true
-	for k, v := range ref.Tags {
-		if val, ok := secret.Tags[k]; !ok || *val != v {
-			tagsFound = false
-			break
+	return matchesTags(ref.Tags, ref.TagMatch, secret.Tags)
+}
+
+// Reports whether tags contain the wanted tags according to mode, an empty mode matches all of them.
+func matchesTags(wanted map[string]string, mode esv1beta1.ExternalSecretFindTagMatch, tags map[string]*string) bool {
+	switch mode {
+	case esv1beta1.ExternalSecretFindTagMatchAny:
+		if len(wanted) == 0 {
+			return true
+		}
+		for k, v := range wanted {
+			if hasTag(tags, k, v) {
+				return true
+			}
+		}
+		return false
+	case esv1beta1.ExternalSecretFindTagMatchKeyOnly:
+		for k := range wanted {
+			if _, ok := tags[k]; !ok {
+				return false
+			}
 		}
+		return true
+	default:
+		for k, v := range wanted {
+			if !hasTag(tags, k, v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Reports whether tags contain the tag k with the value v, a nil tag value only matches an empty v.
+func hasTag(tags map[string]*string, k, v string) bool {
+	val, ok := tags[k]
+	if !ok {
+		return false
+	}
+	if val == nil {
+		return v == ""
 	}
-	return tagsFound
+	return *val == v
 }
@@ -0,0 +1,288 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest/date"
+	pointer "k8s.io/utils/ptr"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	errKeyDisabled = "key %s is disabled"
+
+	// Key Vault reports the region serving the request in this response header.
+	headerKeyVaultRegion = "x-ms-keyvault-region"
+
+	// Keys added by GetSecretMap when IncludeMetadata is set.
+	metadataKeyTags        = "__tags"
+	metadataKeyContentType = "__contentType"
+	metadataKeyUpdated     = "__updated"
+)
+
+// vaultRegionLister looks up the Azure region of a vault.
+type vaultRegionLister interface {
+	VaultRegion(ctx context.Context, vaultURL string) (string, error)
+}
+
+// Discovers the region of a vault from the response headers of a data plane call.
+type responseRegionLister struct {
+	client SecretClient
+}
+
+func (l responseRegionLister) VaultRegion(ctx context.Context, vaultURL string) (string, error) {
+	iter, err := l.client.GetSecretsComplete(ctx, vaultURL, pointer.To(int32(1)))
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecrets, err)
+	if err != nil {
+		return "", err
+	}
+	resp := iter.Response().Response
+	if resp.Response == nil {
+		return "", nil
+	}
+	return resp.Header.Get(headerKeyVaultRegion), nil
+}
+
+// SecretMetadata describes a Key Vault object without its value.
+type SecretMetadata struct {
+	Tags        map[string]string `json:"tags,omitempty"`
+	ContentType string            `json:"contentType,omitempty"`
+	Enabled     bool              `json:"enabled"`
+	// Created and Updated are RFC3339 timestamps, empty if unknown.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+	// HSM reports whether a key is protected by a hardware security module, only set for keys.
+	HSM *bool `json:"hsm,omitempty"`
+	// Region is the Azure region of the vault, empty if unknown.
+	Region string `json:"region,omitempty"`
+}
+
+// GetAllSecretsMetadata returns the metadata of every secret matching the find criteria, keyed by secret name.
+// Only the secret list is read, secret values are never fetched.
+func (a *Azure) GetAllSecretsMetadata(ctx context.Context, ref esv1beta1.ExternalSecretFind) (map[string]SecretMetadata, error) {
+	items, err := a.findSecretItems(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	region := a.vaultRegion(ctx)
+	metadata := make(map[string]SecretMetadata, len(items))
+	for _, item := range items {
+		md := SecretMetadata{
+			Tags:   convertTags(item.Tags),
+			Region: region,
+		}
+		if item.ContentType != nil {
+			md.ContentType = *item.ContentType
+		}
+		if attrs := item.Attributes; attrs != nil {
+			md.setAttributes(attrs.Enabled, attrs.Created, attrs.Updated)
+		}
+		metadata[path.Base(*item.ID)] = md
+	}
+	return metadata, nil
+}
+
+// GetSecretMetadata returns the metadata of the secret, certificate or key referenced by ref, without its value.
+func (a *Azure) GetSecretMetadata(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef) (SecretMetadata, error) {
+	ref, err := normalizeVersion(ref)
+	if err != nil {
+		return SecretMetadata{}, err
+	}
+	objectType, name := a.resolveObjType(ref)
+	if err := a.checkSecretName(name); err != nil {
+		return SecretMetadata{}, err
+	}
+	var md SecretMetadata
+	switch objectType {
+	case defaultObjType:
+		secretResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, name, ref.Version)
+		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+		if err = parseError(err); err != nil {
+			return SecretMetadata{}, err
+		}
+		md.Tags = convertTags(secretResp.Tags)
+		if secretResp.ContentType != nil {
+			md.ContentType = *secretResp.ContentType
+		}
+		if attrs := secretResp.Attributes; attrs != nil {
+			md.setAttributes(attrs.Enabled, attrs.Created, attrs.Updated)
+		}
+	case objectTypeCert:
+		certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, name, ref.Version)
+		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+		if err = parseError(err); err != nil {
+			return SecretMetadata{}, err
+		}
+		md.Tags = convertTags(certResp.Tags)
+		if certResp.ContentType != nil {
+			md.ContentType = *certResp.ContentType
+		}
+		if attrs := certResp.Attributes; attrs != nil {
+			md.setAttributes(attrs.Enabled, attrs.Created, attrs.Updated)
+		}
+	case objectTypeKey:
+		keyResp, err := a.baseClient.GetKey(ctx, *a.provider.VaultURL, name, ref.Version)
+		metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
+		if err = parseError(err); err != nil {
+			return SecretMetadata{}, err
+		}
+		md.Tags = convertTags(keyResp.Tags)
+		if attrs := keyResp.Attributes; attrs != nil {
+			md.setAttributes(attrs.Enabled, attrs.Created, attrs.Updated)
+		}
+		hsm := keyResp.Key != nil && isHSMKeyType(keyResp.Key.Kty)
+		md.HSM = &hsm
+	default:
+		return SecretMetadata{}, fmt.Errorf(errUnknownObjectType, name)
+	}
+	md.Region = a.vaultRegion(ctx)
+	return md, nil
+}
+
+// Adds the tags, content type and update time of the fetched secret to data, for GetSecretMap.
+// Keys of the secret value with the same names are overwritten.
+func addSecretMetadata(secretResp keyvault.SecretBundle, data map[string][]byte) error {
+	tags, err := json.Marshal(convertTags(secretResp.Tags))
+	if err != nil {
+		return err
+	}
+	data[metadataKeyTags] = tags
+	if secretResp.ContentType != nil {
+		data[metadataKeyContentType] = []byte(*secretResp.ContentType)
+	}
+	if attrs := secretResp.Attributes; attrs != nil && attrs.Updated != nil {
+		data[metadataKeyUpdated] = []byte(formatUnixTime(attrs.Updated))
+	}
+	return nil
+}
+
+// Returns the region of the vault, or an empty string if it can not be discovered.
+// The region reported by any earlier response of the vault is used before listing it.
+func (a *Azure) vaultRegion(ctx context.Context) string {
+	host := a.vaultHost()
+	if region, ok := a.regions.get(host); ok {
+		return region
+	}
+	if a.regionLister == nil {
+		return ""
+	}
+	region, err := a.regionLister.VaultRegion(ctx, *a.provider.VaultURL)
+	// a credential only allowed to read single secrets can not list them, the region stays unknown
+	if err != nil && !isForbidden(err) {
+		log.V(1).Info("could not discover vault region", "vault", host, "error", err.Error())
+	}
+	// unknown regions are cached too, so the vault is not listed on every call
+	a.regions.set(host, region)
+	return region
+}
+
+// Caches the regions of the vaults read by a client, keyed by vault host.
+type regionCache struct {
+	mu      sync.Mutex
+	regions map[string]string
+}
+
+func newRegionCache() *regionCache {
+	return &regionCache{regions: make(map[string]string)}
+}
+
+func (c *regionCache) get(host string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	region, ok := c.regions[host]
+	return region, ok
+}
+
+func (c *regionCache) set(host, region string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.regions[host] = region
+}
+
+func (md *SecretMetadata) setAttributes(enabled *bool, created, updated *date.UnixTime) {
+	md.Enabled = enabled != nil && *enabled
+	md.Created = formatUnixTime(created)
+	md.Updated = formatUnixTime(updated)
+}
+
+// Reports whether the key type is backed by a hardware security module, e.g. RSA-HSM or EC-HSM.
+func isHSMKeyType(kty keyvault.JSONWebKeyType) bool {
+	return strings.HasSuffix(string(kty), "-HSM")
+}
+
+func convertTags(tags map[string]*string) map[string]string {
+	converted := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if v != nil {
+			converted[k] = *v
+		}
+	}
+	return converted
+}
+
+// KeyInfo describes the lifecycle attributes of a Key Vault key.
+type KeyInfo struct {
+	Enabled bool `json:"enabled"`
+	// Created, Updated and Expires are RFC3339 timestamps, empty if unknown.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+	Expires string `json:"expires,omitempty"`
+}
+
+// Returns the attributes of a key as JSON. Disabled keys are rejected unless IncludeDisabled is set.
+func (a *Azure) getKeyInfo(ctx context.Context, keyName, version string) ([]byte, error) {
+	keyResp, err := a.baseClient.GetKey(ctx, *a.provider.VaultURL, keyName, version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetKey, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	info := KeyInfo{}
+	if attrs := keyResp.Attributes; attrs != nil {
+		info.Enabled = attrs.Enabled != nil && *attrs.Enabled
+		info.Created = formatUnixTime(attrs.Created)
+		info.Updated = formatUnixTime(attrs.Updated)
+		info.Expires = formatUnixTime(attrs.Expires)
+	}
+	if !info.Enabled && !a.provider.IncludeDisabled {
+		return nil, fmt.Errorf(errKeyDisabled, keyName)
+	}
+	return json.Marshal(info)
+}
+
+func formatUnixTime(t *date.UnixTime) string {
+	if t == nil {
+		return ""
+	}
+	return time.Time(*t).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,171 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	defaultMaxRetries    = 3
+	defaultRetryInterval = 500 * time.Millisecond
+	// Upper bound of a single backoff, also applied to the Retry-After header of the vault.
+	maxRetryDelay = time.Minute
+)
+
+// Wraps a SecretClient to retry reads failing with throttling or server errors.
+// Writes are passed through, they are not necessarily idempotent.
+type retryingClient struct {
+	SecretClient
+	maxRetries int
+	interval   time.Duration
+}
+
+func newRetryingClient(client SecretClient, provider *esv1beta1.AzureKVProvider) *retryingClient {
+	c := &retryingClient{SecretClient: client, maxRetries: defaultMaxRetries, interval: defaultRetryInterval}
+	if provider.MaxRetries != nil {
+		c.maxRetries = int(*provider.MaxRetries)
+	}
+	if provider.RetryInterval != nil && provider.RetryInterval.Duration > 0 {
+		c.interval = provider.RetryInterval.Duration
+	}
+	return c
+}
+
+func (c *retryingClient) GetKey(ctx context.Context, vaultBaseURL, keyName, keyVersion string) (result keyvault.KeyBundle, err error) {
+	err = c.retry(ctx, func() error {
+		result, err = c.SecretClient.GetKey(ctx, vaultBaseURL, keyName, keyVersion)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingClient) GetSecret(ctx context.Context, vaultBaseURL, secretName, secretVersion string) (result keyvault.SecretBundle, err error) {
+	err = c.retry(ctx, func() error {
+		result, err = c.SecretClient.GetSecret(ctx, vaultBaseURL, secretName, secretVersion)
+		return err
+	})
+	return result, err
+}
+
+// Retries the request of the first page, nextSecretPage retries the next ones.
+func (c *retryingClient) GetSecretsComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.SecretListResultIterator, err error) {
+	err = c.retry(ctx, func() error {
+		result, err = c.SecretClient.GetSecretsComplete(ctx, vaultBaseURL, maxresults)
+		return err
+	})
+	return result, err
+}
+
+// Retries the request of the first page, nextSecretPage retries the next ones.
+func (c *retryingClient) GetSecretVersionsComplete(ctx context.Context, vaultBaseURL, secretName string, maxresults *int32) (result keyvault.SecretListResultIterator, err error) {
+	err = c.retry(ctx, func() error {
+		result, err = c.SecretClient.GetSecretVersionsComplete(ctx, vaultBaseURL, secretName, maxresults)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingClient) GetCertificate(ctx context.Context, vaultBaseURL, certificateName, certificateVersion string) (result keyvault.CertificateBundle, err error) {
+	err = c.retry(ctx, func() error {
+		result, err = c.SecretClient.GetCertificate(ctx, vaultBaseURL, certificateName, certificateVersion)
+		return err
+	})
+	return result, err
+}
+
+// Retries the request of the first page, nextListPage retries the next ones.
+func (c *retryingClient) GetCertificatesComplete(ctx context.Context, vaultBaseURL string, maxresults *int32) (result keyvault.CertificateListResultIterator, err error) {
+	err = c.retry(ctx, func() error {
+		result, err = c.SecretClient.GetCertificatesComplete(ctx, vaultBaseURL, maxresults)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingClient) GetCertificatePolicy(ctx context.Context, vaultBaseURL, certificateName string) (result keyvault.CertificatePolicy, err error) {
+	err = c.retry(ctx, func() error {
+		result, err = c.SecretClient.GetCertificatePolicy(ctx, vaultBaseURL, certificateName)
+		return err
+	})
+	return result, err
+}
+
+func (c *retryingClient) retry(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= c.maxRetries || !isTransient(err) {
+			return err
+		}
+		delay := retryDelay(err, c.interval, attempt)
+		log.V(1).Info("transient error from the vault, retrying", "attempt", attempt+1, "delay", delay.String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Returns the delay before retrying err. The Retry-After header of the response is honored,
+// otherwise interval is doubled on every attempt, with jitter so clients do not retry in lockstep.
+func retryDelay(err error, interval time.Duration, attempt int) time.Duration {
+	if delay, ok := retryAfter(err); ok {
+		return delay
+	}
+	backoff := maxRetryDelay
+	if attempt < 32 && interval<<attempt < maxRetryDelay {
+		backoff = interval << attempt
+	}
+	//nolint:gosec // the jitter does not need a secure source
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// Parses the Retry-After header of the response, given in seconds or as an HTTP date.
+func retryAfter(err error) (time.Duration, bool) {
+	aerr := autorest.DetailedError{}
+	if !errors.As(err, &aerr) || aerr.Response == nil {
+		return 0, false
+	}
+	header := aerr.Response.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	var delay time.Duration
+	if seconds, atoiErr := strconv.Atoi(header); atoiErr == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, parseErr := http.ParseTime(header); parseErr == nil {
+		delay = time.Until(date)
+	} else {
+		return 0, false
+	}
+	switch {
+	case delay < 0:
+		return 0, true
+	case delay > maxRetryDelay:
+		return maxRetryDelay, true
+	}
+	return delay, true
+}
@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+// SecretVersion describes a version of a Key Vault secret, without its value.
+type SecretVersion struct {
+	Version string `json:"version"`
+	Enabled bool   `json:"enabled"`
+	// Created and Updated are RFC3339 timestamps, empty if unknown.
+	Created string `json:"created,omitempty"`
+	Updated string `json:"updated,omitempty"`
+}
+
+// Returns all versions of a secret as a JSON array, oldest first, e.g. for audits or to pick a version to pin.
+// Disabled versions are included with enabled set to false.
+func (a *Azure) getSecretVersions(ctx context.Context, secretName string) ([]byte, error) {
+	iter, err := a.baseClient.GetSecretVersionsComplete(ctx, *a.provider.VaultURL, secretName, a.provider.MaxResults)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecretVersions, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]SecretVersion, 0)
+	for iter.NotDone() {
+		item := iter.Value()
+		if item.ID != nil {
+			version := SecretVersion{Version: path.Base(*item.ID)}
+			if attrs := item.Attributes; attrs != nil {
+				version.Enabled = attrs.Enabled != nil && *attrs.Enabled
+				version.Created = formatUnixTime(attrs.Created)
+				version.Updated = formatUnixTime(attrs.Updated)
+			}
+			versions = append(versions, version)
+		}
+
+		err = a.nextSecretPage(ctx, &iter)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(versions) == 0 {
+		return nil, esv1beta1.NoSecretError{}
+	}
+	// RFC3339 timestamps in UTC sort chronologically
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].Created != versions[j].Created {
+			return versions[i].Created < versions[j].Created
+		}
+		return versions[i].Version < versions[j].Version
+	})
+	return json.Marshal(versions)
+}
@@ -0,0 +1,120 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+const (
+	// Upper bound of the rendered output, so a template can not exhaust the controller memory.
+	maxTemplateOutput = 1 << 20
+	// Upper bound of the rendering time, so nested ranges over the data can not stall the reconcile.
+	templateTimeout = 5 * time.Second
+
+	errMissingTemplateData = "missing data secret for template %s, set the property to the name of the data secret"
+	errTemplateData        = "data secret %s of template %s is not a JSON object: %w"
+	errParseTemplate       = "could not parse template %s: %w"
+	errExecuteTemplate     = "could not render template %s: %w"
+	errTemplateTooLarge    = "rendered output exceeds %d bytes"
+	errTemplateTimeout     = "rendering template %s did not finish in time: %w"
+)
+
+// Writes to a buffer, failing once more than limit bytes are written or ctx is done.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+	ctx   context.Context
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf(errTemplateTooLarge, b.limit)
+	}
+	return b.Buffer.Write(p)
+}
+
+// renderTemplate executes the Go template stored in templateName with the JSON object
+// stored in the data secret named by ref.Property as context.
+func (a *Azure) renderTemplate(ctx context.Context, ref esv1beta1.ExternalSecretDataRemoteRef, templateName string) ([]byte, error) {
+	dataName := ref.Property
+	if dataName == "" {
+		return nil, fmt.Errorf(errMissingTemplateData, templateName)
+	}
+	if err := a.checkSecretName(dataName); err != nil {
+		return nil, err
+	}
+	templateResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, templateName, ref.Version)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	if err = parseError(err); err != nil {
+		return nil, err
+	}
+	dataResp, err := a.baseClient.GetSecret(ctx, *a.provider.VaultURL, dataName, "")
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetSecret, err)
+	if err = parseError(err); err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	if dataResp.Value == nil {
+		return nil, fmt.Errorf(errTemplateData, dataName, templateName, errors.New(errInvalidJSON))
+	}
+	if err := json.Unmarshal([]byte(*dataResp.Value), &data); err != nil {
+		return nil, fmt.Errorf(errTemplateData, dataName, templateName, err)
+	}
+	if templateResp.Value == nil {
+		return []byte{}, nil
+	}
+	tpl, err := template.New(templateName).Option("missingkey=error").Parse(*templateResp.Value)
+	if err != nil {
+		return nil, fmt.Errorf(errParseTemplate, templateName, err)
+	}
+	return executeTemplate(ctx, tpl, data)
+}
+
+// Executes tpl until it completes or the deadline passes.
+// text/template can not be interrupted, so a template still running at the deadline is abandoned,
+// and stops at its next write.
+func executeTemplate(ctx context.Context, tpl *template.Template, data map[string]interface{}) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, templateTimeout)
+	defer cancel()
+	out := &limitedBuffer{limit: maxTemplateOutput, ctx: ctx}
+	done := make(chan error, 1)
+	go func() {
+		done <- tpl.Execute(out, data)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return nil, fmt.Errorf(errExecuteTemplate, tpl.Name(), err)
+		}
+		return out.Bytes(), nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf(errTemplateTimeout, tpl.Name(), ctx.Err())
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+	"github.com/external-secrets/external-secrets/pkg/constants"
+	"github.com/external-secrets/external-secrets/pkg/metrics"
+)
+
+// Selects a certificate by the SHA-1 thumbprint of its current version, e.g. cert/thumbprint:ABCD...
+const certThumbprintPrefix = "thumbprint:"
+
+const (
+	errInvalidThumbprint   = "invalid certificate thumbprint %q, expected a hex encoded SHA-1 hash"
+	errThumbprintNotFound  = "%w: no enabled certificate has the thumbprint %s"
+	errThumbprintAmbiguous = "certificates %s share the thumbprint %s"
+)
+
+// Reports whether the name of a cert ref is a thumbprint selector and returns the thumbprint.
+func parseCertThumbprint(certName string) (string, bool) {
+	if !strings.HasPrefix(certName, certThumbprintPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(certName, certThumbprintPrefix), true
+}
+
+// Returns the CER contents of the only enabled certificate whose current version has the thumbprint.
+// Key Vault can not look up certificates by thumbprint, so all certificates are listed and compared.
+func (a *Azure) getCertificateByThumbprint(ctx context.Context, thumbprint string) ([]byte, error) {
+	want, err := hex.DecodeString(strings.ReplaceAll(thumbprint, ":", ""))
+	if err != nil || len(want) == 0 {
+		return nil, fmt.Errorf(errInvalidThumbprint, thumbprint)
+	}
+	certName, err := a.findCertificateByThumbprint(ctx, thumbprint, want)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkSecretName(certName); err != nil {
+		return nil, err
+	}
+	certResp, err := a.baseClient.GetCertificate(ctx, *a.provider.VaultURL, certName, "")
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificate, err)
+	err = parseError(err)
+	if err != nil {
+		return nil, err
+	}
+	if certResp.Cer == nil {
+		return nil, errors.New(errMissingCertificate)
+	}
+	return *certResp.Cer, nil
+}
+
+// Returns the name of the only enabled certificate whose thumbprint is want.
+func (a *Azure) findCertificateByThumbprint(ctx context.Context, thumbprint string, want []byte) (string, error) {
+	certListIter, err := a.baseClient.GetCertificatesComplete(ctx, *a.provider.VaultURL, a.provider.MaxResults)
+	metrics.ObserveAPICall(constants.ProviderAzureKV, constants.CallAzureKVGetCertificates, err)
+	err = parseError(err)
+	if err != nil {
+		return "", err
+	}
+	matches := make([]string, 0, 1)
+	for certListIter.NotDone() {
+		item := certListIter.Value()
+		if item.ID != nil && item.X509Thumbprint != nil && isEnabledCertificate(item) {
+			// the thumbprint is returned base64url encoded, with or without padding
+			got, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(*item.X509Thumbprint, "="))
+			if err == nil && bytes.Equal(got, want) {
+				matches = append(matches, path.Base(*item.ID))
+			}
+		}
+
+		err = a.nextListPage(ctx, objectTypeCert, certListIter.NextWithContext)
+		if err != nil {
+			return "", err
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf(errThumbprintNotFound, esv1beta1.NoSecretErr, thumbprint)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf(errThumbprintAmbiguous, strings.Join(matches, ", "), thumbprint)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/2016-10-01/keyvault"
+	lru "github.com/hashicorp/golang-lru"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Maximum number of secrets cached by the provider, the least recently used are evicted.
+const valueCacheSize = 1024
+
+// The secret bundles fetched by all clients of the provider. A client only lives for
+// a single reconcile, so its bundles are kept here to be reused by later reconciles.
+var sharedValues = newValueLRU()
+
+func newValueLRU() *lru.Cache {
+	// only fails for a non-positive size
+	cache, _ := lru.New(valueCacheSize)
+	return cache
+}
+
+// The view of a client on the cached bundles: entries expire after the store's CacheTTL
+// and are scoped to the credential of the client. A nil cache is disabled.
+type valueCache struct {
+	lru   *lru.Cache
+	ttl   time.Duration
+	scope string
+}
+
+type valueCacheEntry struct {
+	bundle  keyvault.SecretBundle
+	expires time.Time
+}
+
+// Returns nil if ttl is not set or not positive.
+func newValueCache(values *lru.Cache, ttl *metav1.Duration, scope string) *valueCache {
+	if ttl == nil || ttl.Duration <= 0 {
+		return nil
+	}
+	return &valueCache{lru: values, ttl: ttl.Duration, scope: scope}
+}
+
+// Scopes the cached bundles to the namespace and credential of the client, so a client never reads
+// a bundle its own credential was not granted. Stores authenticating the same way share the entries.
+func (a *Azure) valueScope() string {
+	return a.namespace + "|" + a.authIdentity()
+}
+
+// Builds the cache key. The latest version, an empty version, is cached separately from pinned versions.
+func valueCacheKey(vaultURL, objectType, secretName, version string) string {
+	return strings.Join([]string{vaultURL, objectType, secretName, version}, "|")
+}
+
+// Returns the cached bundle for key, expired entries are removed.
+func (c *valueCache) get(key string, now time.Time) (keyvault.SecretBundle, bool) {
+	if c == nil {
+		return keyvault.SecretBundle{}, false
+	}
+	val, ok := c.lru.Get(c.scope + "|" + key)
+	if !ok {
+		return keyvault.SecretBundle{}, false
+	}
+	entry := val.(valueCacheEntry)
+	if !now.Before(entry.expires) {
+		c.lru.Remove(c.scope + "|" + key)
+		return keyvault.SecretBundle{}, false
+	}
+	return entry.bundle, true
+}
+
+func (c *valueCache) add(key string, bundle keyvault.SecretBundle, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.lru.Add(c.scope+"|"+key, valueCacheEntry{bundle: bundle, expires: now.Add(c.ttl)})
+}
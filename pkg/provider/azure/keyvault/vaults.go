@@ -0,0 +1,91 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keyvault
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	esv1beta1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1beta1"
+)
+
+const (
+	errVaultNotAllowed  = "vault %s of key %s is not in the allowedVaults of the store"
+	errInvalidVaultName = "invalid allowedVaults entry %q: vault names have 3 to 24 letters, digits and dashes"
+	errEmptyVaultPrefix = "key %s has an empty vault prefix"
+)
+
+// Key Vault names start with a letter and only contain letters, digits and dashes.
+var vaultNameRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{2,23}$`)
+
+func validateAllowedVaults(p *esv1beta1.AzureKVProvider) error {
+	for _, name := range p.AllowedVaults {
+		if !vaultNameRegexp.MatchString(name) {
+			return fmt.Errorf(errInvalidVaultName, name)
+		}
+	}
+	return nil
+}
+
+// Splits a <vault>: prefix from the key of ref. Key Vault object names can not contain colons,
+// so the prefix is unambiguous. A colon after the object type, like in cert/thumbprint:<thumbprint>,
+// is not a prefix. Returns an empty vault name if the key has no prefix.
+func splitVaultPrefix(ref esv1beta1.ExternalSecretDataRemoteRef) (string, esv1beta1.ExternalSecretDataRemoteRef, error) {
+	vault, key, found := strings.Cut(ref.Key, ":")
+	if !found || strings.Contains(vault, "/") {
+		return "", ref, nil
+	}
+	if vault == "" {
+		return "", ref, fmt.Errorf(errEmptyVaultPrefix, ref.Key)
+	}
+	ref.Key = key
+	return vault, ref, nil
+}
+
+// Returns the client reading the vault selected by the key of ref, and ref without the vault prefix.
+// Keys without a prefix are read by a itself. Other vaults must be listed in AllowedVaults, their URL
+// is built from the DNS suffix of the store's cloud so they share its token audience.
+func (a *Azure) forRefVault(ref esv1beta1.ExternalSecretDataRemoteRef) (*Azure, esv1beta1.ExternalSecretDataRemoteRef, error) {
+	vault, ref, err := splitVaultPrefix(ref)
+	if err != nil || vault == "" {
+		return a, ref, err
+	}
+	if a.names == nil || !a.names.vaults[strings.ToLower(vault)] {
+		return nil, ref, fmt.Errorf(errVaultNotAllowed, vault, vault+":"+ref.Key)
+	}
+	return a.withVaultURL(fmt.Sprintf("https://%s.%s/", strings.ToLower(vault), a.vaultDNSSuffix())), ref, nil
+}
+
+// Returns a client for another vault of the same store, sharing the authorizer and caches.
+func (a *Azure) withVaultURL(vaultURL string) *Azure {
+	provider := *a.provider
+	provider.VaultURL = &vaultURL
+	return &Azure{
+		crClient:        a.crClient,
+		kubeClient:      a.kubeClient,
+		store:           a.store,
+		provider:        &provider,
+		baseClient:      a.baseClient,
+		namespace:       a.namespace,
+		clock:           a.clock,
+		forbidden:       a.forbidden,
+		regionLister:    a.regionLister,
+		regions:         a.regions,
+		claimsRefresher: a.claimsRefresher,
+		values:          a.values,
+		names:           a.names,
+	}
+}